@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// timeInfo is the JSON model of an epoch, shared by the query command
+// (-json) and the REST API of the serve command.
+type timeInfo struct {
+	UTC        string      `json:"utc"`
+	SatSys     gnss.SatSys `json:"satsys"`
+	SysTime    string      `json:"sys_time"`
+	TAI        string      `json:"tai"`
+	Week       int         `json:"week"`
+	Dow        int         `json:"dow"`
+	Sow        float64     `json:"sow"`
+	Year       int         `json:"year"`
+	Doy        int         `json:"doy"`
+	Hour       int         `json:"hour"`
+	Minute     int         `json:"minute"`
+	Second     int         `json:"second"`
+	Nanosecond int         `json:"nanosecond"`
+	Sod        float64     `json:"sod"`
+	MJD        int         `json:"mjd"`
+	FracMJD    float64     `json:"fractional_mjd"` // MJD with the fraction of the day, e.g. 60432.5
+	Unix       float64     `json:"unix"`
+	Date       string      `json:"date,omitempty"` // date in the notation of -dates of query, if not calendar
+}
+
+// newTimeInfo returns timeInfo of t (UTC) in the system time of sys.
+func newTimeInfo(sys gnss.SystemInfo, t time.Time) (timeInfo, error) {
+	t = t.UTC()
+	g, err := gnss.TimeOf(sys.Name, t)
+	if err != nil {
+		return timeInfo{}, err
+	}
+	day := t.Truncate(oneDay)
+	hour, min, sec := t.Clock()
+
+	return timeInfo{
+		UTC:        t.Format(time.RFC3339Nano),
+		SatSys:     sys.Name,
+		SysTime:    formatSeconds(t.Add(sys.UTCOffset(t))),
+		TAI:        formatSeconds(gnss.UTCToTAI(t)),
+		Week:       g.Week,
+		Dow:        g.Dow(),
+		Sow:        g.Seconds(),
+		Year:       t.Year(),
+		Doy:        doy(day),
+		Hour:       hour,
+		Minute:     min,
+		Second:     sec,
+		Nanosecond: t.Nanosecond(),
+		Sod:        t.Sub(day).Seconds(),
+		MJD:        gnss.MJD(t),
+		FracMJD:    gnss.FractionalMJD(t),
+		Unix:       float64(t.UnixNano()) / 1e9,
+	}, nil
+}
+
+// weekInfo is the JSON model of a GNSS week.
+type weekInfo struct {
+	SatSys gnss.SatSys `json:"satsys"`
+	Week   int         `json:"week"`
+	Start  string      `json:"start"`
+	End    string      `json:"end"`
+	Days   []timeInfo  `json:"days"`
+}
+
+// apiError is the JSON model of an error of the REST API.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// registerAPI registers the handlers of the REST API to mux.
+//
+//	/api/v1/convert?epoch=...   an epoch in the forms of the query command,
+//	                            or in the layout given by parse
+//	/api/v1/date/{date}         a date, yyyy-mm-dd or yyyy:ddd
+//	/api/v1/week/{week}         the days of a GNSS week
+//	/api/v1/month/{yyyy}/{mm}   the grid of a month (calendar.MonthGrid)
+//
+// The satellite system is given by the satsys query parameter, and the
+// first weekday of month grids by first. The
+// handlers are wrapped by wrap, e.g. to cache the responses.
+func registerAPI(mux *http.ServeMux, wrap func(http.HandlerFunc) http.HandlerFunc) {
+	mux.HandleFunc("/api/v1/convert", wrap(handleConvert))
+	mux.HandleFunc("/api/v1/date/", wrap(handleDate))
+	mux.HandleFunc("/api/v1/week/", wrap(handleWeek))
+	mux.HandleFunc("/api/v1/month/", wrap(handleMonth))
+}
+
+func handleConvert(w http.ResponseWriter, r *http.Request) {
+	sys, err := apiSatSys(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+
+	q := r.URL.Query()
+	t, err := parseEpoch(sys, q.Get("epoch"), q.Get("parse"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+
+	writeTimeInfo(w, sys, t)
+}
+
+// parseEpoch parses epoch in the layout if given, or in the forms of the
+// query command.
+func parseEpoch(sys gnss.SystemInfo, epoch, layout string) (time.Time, error) {
+	if epoch == "" {
+		return time.Time{}, fmt.Errorf("epoch is not given")
+	}
+	if layout != "" {
+		return gnss.Parse(layout, sys.Name, epoch)
+	}
+	return gnss.ParseTime(sys.Name, epoch)
+}
+
+func handleDate(w http.ResponseWriter, r *http.Request) {
+	sys, err := apiSatSys(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+
+	date, err := parseDate(strings.TrimPrefix(r.URL.Path, "/api/v1/date/"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+
+	writeTimeInfo(w, sys, date)
+}
+
+// parseDate parses a date given as yyyy-mm-dd or yyyy:ddd.
+func parseDate(s string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", s); err == nil {
+		return date, nil
+	}
+	yd, err := gnss.ParseYearDoy(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %s", s)
+	}
+	return yd.Time(), nil
+}
+
+func handleWeek(w http.ResponseWriter, r *http.Request) {
+	sys, err := apiSatSys(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+
+	arg := strings.TrimPrefix(r.URL.Path, "/api/v1/week/")
+	week, err := strconv.Atoi(arg)
+	if err != nil || week < 0 {
+		writeJSON(w, http.StatusBadRequest, apiError{"invalid week: " + arg})
+		return
+	}
+	info, err := newWeekInfo(sys, week)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, info)
+}
+
+func handleMonth(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/month")
+	if strings.Count(strings.Trim(path, "/"), "/") != 1 {
+		writeJSON(w, http.StatusBadRequest, apiError{"invalid month: " + path})
+		return
+	}
+	cal, err := calFromQuery(path, r.URL.Query())
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, cal.MonthGrid(cal.RefDate.Year(), cal.RefDate.Month()))
+}
+
+// newWeekInfo returns weekInfo of week of sys.
+func newWeekInfo(sys gnss.SystemInfo, week int) (weekInfo, error) {
+	if sys.EpochFn != nil {
+		return weekInfo{}, fmt.Errorf("week of %s is ambiguous", sys.Name)
+	}
+
+	// weeks are of the years of calendars, up to 9999
+	if last := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC); week > gnss.WeekNumber(last, sys.Epoch) {
+		return weekInfo{}, fmt.Errorf("%s week %d is beyond the year 9999", sys.Name, week)
+	}
+
+	start := sys.Epoch.AddDate(0, 0, 7*week)
+	info := weekInfo{
+		SatSys: sys.Name,
+		Week:   week,
+		Start:  start.Format("2006-01-02"),
+		End:    start.Add(oneWeek - oneDay).Format("2006-01-02"),
+	}
+	for date := start; date.Before(start.Add(oneWeek)); date = date.Add(oneDay) {
+		ti, err := newTimeInfo(sys, date)
+		if err != nil {
+			return weekInfo{}, err
+		}
+		info.Days = append(info.Days, ti)
+	}
+	return info, nil
+}
+
+// apiSatSys returns the satellite system of the satsys query parameter.
+func apiSatSys(r *http.Request) (gnss.SystemInfo, error) {
+	return lookupSatSysOrGPS(r.URL.Query().Get("satsys"))
+}
+
+// lookupSatSysOrGPS is lookupSatSys defaulting to GPS for an empty name.
+func lookupSatSysOrGPS(name string) (gnss.SystemInfo, error) {
+	if name == "" {
+		name = string(gnss.SYSGPS)
+	}
+	return lookupSatSys(name)
+}
+
+func writeTimeInfo(w http.ResponseWriter, sys gnss.SystemInfo, t time.Time) {
+	ti, err := newTimeInfo(sys, t)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, ti)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
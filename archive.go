@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runArchive prints the remote directories of archives for a date.
+func runArchive(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("archive", flag.ExitOnError)
+	name := fs.String("archive", "", "archive, e.g. 'CDDIS', 'IGN', or 'BKG'; all archives if not given")
+	dataType := fs.String("type", gnss.ArchiveDaily, "data type; 'daily', 'nav', 'met', 'hourly', 'highrate', or 'products'")
+	hour := fs.Int("hour", 0, "hour for hourly and high-rate data")
+	pathOnly := fs.Bool("path", false, "prints the directory path instead of the URL")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal archive [-archive name] [-type type] [-hour hh] [-path] [yyyy-mm-dd]\n")
+	}
+	fs.Parse(args)
+
+	date := time.Now().UTC().Truncate(oneDay)
+	if fs.NArg() > 0 {
+		var err error
+		if date, err = time.Parse("2006-01-02", fs.Arg(0)); err != nil {
+			return fmt.Errorf("invalid date: %s, error: %v", fs.Arg(0), err)
+		}
+	}
+	if *hour < 0 || 23 < *hour {
+		return fmt.Errorf("invalid hour: %d", *hour)
+	}
+	date = date.Add(time.Duration(*hour) * time.Hour)
+
+	archives := gnss.Archives()
+	if *name != "" {
+		a, ok := gnss.LookupArchive(*name)
+		if !ok {
+			return fmt.Errorf("unknown archive: '%s'", *name)
+		}
+		archives = []gnss.Archive{a}
+	}
+
+	for _, a := range archives {
+		var s string
+		var err error
+		if *pathOnly {
+			s, err = a.Path(*dataType, date)
+		} else {
+			s, err = a.URL(*dataType, date)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(archives) == 1 {
+			fmt.Println(s)
+		} else {
+			fmt.Printf("%-7s%s\n", a.Name, s)
+		}
+	}
+
+	return nil
+}
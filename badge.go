@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// badgeSVG is a flat badge in the style of shields.io.
+var badgeSVG = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+<title>{{.Label}}: {{.Message}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)">
+<rect width="{{.LabelWidth}}" height="20" fill="#555"/>
+<rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="#007ec6"/>
+<rect width="{{.Width}}" height="20" fill="url(#s)"/>
+</g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="15" fill="#010101" fill-opacity=".3">{{.Label}}</text>
+<text x="{{.LabelX}}" y="14">{{.Label}}</text>
+<text x="{{.MessageX}}" y="15" fill="#010101" fill-opacity=".3">{{.Message}}</text>
+<text x="{{.MessageX}}" y="14">{{.Message}}</text>
+</g>
+</svg>
+`))
+
+// writeBadge writes the SVG badge of the GNSS week and doy of t,
+// e.g. "GPS week | 2313 / DOY 123".
+func writeBadge(w io.Writer, sys gnss.SystemInfo, t time.Time) error {
+	g, err := gnss.TimeOf(sys.Name, t)
+	if err != nil {
+		return err
+	}
+	label := fmt.Sprintf("%s week", sys.Name)
+	message := fmt.Sprintf("%d / DOY %03d", g.Week, t.UTC().YearDay())
+
+	// widths are estimated of 7 px per character of Verdana 11 px
+	lw, mw := 7*len(label)+10, 7*len(message)+10
+	return badgeSVG.Execute(w, map[string]interface{}{
+		"Label":        label,
+		"Message":      message,
+		"Width":        lw + mw,
+		"LabelWidth":   lw,
+		"MessageWidth": mw,
+		"LabelX":       lw / 2,
+		"MessageX":     lw + mw/2,
+	})
+}
+
+// handleBadge serves the badge of the current time; satsys=... selects the
+// satellite system.
+func handleBadge(w http.ResponseWriter, r *http.Request) {
+	sys, err := apiSatSys(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "max-age=300")
+	writeBadge(w, sys, time.Now())
+}
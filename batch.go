@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"io"
+)
+
+// task renders a part of the output. The output rendered before an error
+// is returned with it.
+type task func() ([]byte, error)
+
+// writeOrdered runs the tasks sent by produce on jobs goroutines, and writes
+// their outputs to w in the order the tasks are sent. It stops at the first
+// error of a task, of produce, or of w, or when ctx is done; send returns
+// false once it stops. The output of the task failing is written before its
+// error is returned.
+func writeOrdered(ctx context.Context, w io.Writer, jobs int, produce func(send func(task) bool) error) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		b   []byte
+		err error
+	}
+	work := make(chan func(), jobs)
+	order := make(chan chan result, 2*jobs) // results in the order of the tasks
+	perr := make(chan error, 1)
+
+	// producer
+	go func() {
+		defer close(order)
+		defer close(work)
+		perr <- produce(func(t task) bool {
+			rc := make(chan result, 1)
+			select {
+			case order <- rc:
+			case <-ctx.Done():
+				return false
+			}
+			select {
+			case work <- func() { b, err := t(); rc <- result{b, err} }:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	// workers
+	for i := 0; i < jobs; i++ {
+		go func() {
+			for f := range work {
+				f()
+			}
+		}()
+	}
+
+	// writer
+	for rc := range order {
+		var r result
+		select {
+		case r = <-rc:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if _, err := w.Write(r.b); err != nil {
+			return err
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+	if err := <-perr; err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteOrdered(t *testing.T) {
+	var b bytes.Buffer
+	errBad := errors.New("bad")
+	err := writeOrdered(context.Background(), &b, 4, func(send func(task) bool) error {
+		for i := 0; i < 10; i++ {
+			send(func() ([]byte, error) {
+				if i == 5 {
+					return []byte("partial\n"), errBad
+				}
+				return fmt.Appendf(nil, "%d\n", i), nil
+			})
+		}
+		return nil
+	})
+	if !errors.Is(err, errBad) {
+		t.Errorf("error = %v, want %v", err, errBad)
+	}
+	if want := "0\n1\n2\n3\n4\npartial\n"; b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestQueryLines(t *testing.T) {
+	newQuery := func(w io.Writer) func(i int, arg string) error {
+		return func(i int, arg string) error {
+			if arg == "bad" {
+				return errors.New("unknown time format")
+			}
+			_, err := fmt.Fprintf(w, "%d %s\n", i, arg)
+			return err
+		}
+	}
+	in := strings.Repeat("a\n", queryBatch+1) + "\nb\nbad\nc\n"
+	var b bytes.Buffer
+	err := queryLines(context.Background(), strings.NewReader(in), &b, 2, newQuery)
+	if err == nil || !strings.HasPrefix(err.Error(), fmt.Sprintf("line %d: ", queryBatch+4)) {
+		t.Errorf("error = %v, want of line %d", err, queryBatch+4)
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != queryBatch+2 || lines[queryBatch+1] != fmt.Sprintf("%d b", queryBatch+1) {
+		t.Errorf("output has %d lines ending with %q, want %d lines ending with %q", len(lines), lines[len(lines)-1], queryBatch+2, fmt.Sprintf("%d b", queryBatch+1))
+	}
+}
@@ -0,0 +1,218 @@
+// Package cache keeps files downloaded by gnsscal, such as leap-seconds.list
+// and the IERS finals2000A file, in a cache directory.
+//
+// The directory is $GNSSCAL_CACHE_DIR if set, and otherwise gnsscal in the
+// user cache directory ($XDG_CACHE_HOME, or ~/.cache, on Unix). SetDir
+// points it somewhere else, e.g. to a volume of a container. Each file is
+// stored with its metadata, name.meta, recording where and when it was
+// downloaded and when it expires.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// dir is the cache directory set by SetDir.
+var dir string
+
+// client downloads the files; the requests are also bounded by their
+// contexts.
+var client = &http.Client{Timeout: time.Minute}
+
+// logger receives the diagnostics of the package. It discards them unless
+// SetLogger is called.
+var logger = slog.New(slog.DiscardHandler)
+
+// SetLogger sets the logger of the diagnostics of the package, which are
+// logged at the debug level. A nil l discards them.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(slog.DiscardHandler)
+	}
+	logger = l
+}
+
+// SetDir sets the cache directory. An empty d restores the default.
+func SetDir(d string) {
+	dir = d
+}
+
+// Dir returns the cache directory.
+func Dir() (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+	if d := os.Getenv("GNSSCAL_CACHE_DIR"); d != "" {
+		return d, nil
+	}
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "gnsscal"), nil
+}
+
+// Meta is the metadata of a cached file.
+type Meta struct {
+	URL     string    `json:"url"`
+	Fetched time.Time `json:"fetched"`
+	Expires time.Time `json:"expires"`
+}
+
+// Source is a file to be cached.
+type Source struct {
+	Name   string        // file name in the cache directory
+	URL    string        // URL to download the file from
+	MaxAge time.Duration // age after which the file is downloaded again
+
+	// Check validates the contents of the file and returns the time after
+	// which they are out of date, or the zero time if unknown. Nil accepts
+	// any contents.
+	Check func(data []byte) (expire time.Time, err error)
+}
+
+// Get returns the file of src from the cache, downloading it if it is not
+// cached, is older than src.MaxAge, has expired, or refresh is true.
+//
+// If the download fails, Get returns the cached file, if any, along with the
+// error; data is nil if nothing can be used.
+func Get(ctx context.Context, src Source, refresh bool) (data []byte, meta Meta, err error) {
+	d, err := Dir()
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	path := filepath.Join(d, src.Name)
+
+	data, meta, cerr := read(path, src)
+	now := time.Now()
+	switch {
+	case cerr != nil:
+		logger.Debug("cache miss", "path", path, "error", cerr)
+	case refresh:
+		logger.Debug("cache refresh requested", "path", path)
+	case !meta.Expires.IsZero() && now.After(meta.Expires):
+		logger.Debug("cache expired", "path", path, "expires", meta.Expires)
+	case src.MaxAge > 0 && now.Sub(meta.Fetched) > src.MaxAge:
+		logger.Debug("cache stale", "path", path, "fetched", meta.Fetched)
+	default:
+		logger.Debug("cache hit", "path", path, "expires", meta.Expires)
+		return data, meta, nil
+	}
+
+	fetched, fmeta, err := fetch(ctx, src)
+	if err != nil {
+		if cerr != nil {
+			return nil, Meta{}, err
+		}
+		return data, meta, err
+	}
+	if err := write(path, fetched, fmeta); err != nil {
+		logger.Debug("cache not written", "path", path, "error", err)
+	}
+	return fetched, fmeta, nil
+}
+
+// read returns the cached file of path and its metadata. The metadata of a
+// file cached without it, e.g. by an older version, are made of the
+// modification time of the file.
+func read(path string, src Source) (data []byte, meta Meta, err error) {
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	expire, err := check(src, data)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	if b, err := os.ReadFile(path + ".meta"); err == nil && json.Unmarshal(b, &meta) == nil {
+		return data, meta, nil
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	return data, Meta{URL: src.URL, Fetched: fi.ModTime(), Expires: expire}, nil
+}
+
+// fetch downloads the file of src.
+func fetch(ctx context.Context, src Source) (data []byte, meta Meta, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URL, nil)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	defer resp.Body.Close()
+	logger.Debug("fetched", "url", src.URL, "status", resp.Status, "elapsed", time.Since(start))
+	if resp.StatusCode != http.StatusOK {
+		return nil, Meta{}, fmt.Errorf("%s: %s", src.URL, resp.Status)
+	}
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+	expire, err := check(src, data)
+	if err != nil {
+		return nil, Meta{}, fmt.Errorf("%s: %v", src.URL, err)
+	}
+	return data, Meta{URL: src.URL, Fetched: start.UTC(), Expires: expire}, nil
+}
+
+// check validates data by src.Check.
+func check(src Source, data []byte) (time.Time, error) {
+	if src.Check == nil {
+		return time.Time{}, nil
+	}
+	return src.Check(data)
+}
+
+// write saves data and its metadata to path. The files are renamed into
+// place so that readers never see a partial file.
+func write(path string, data []byte, meta Meta) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(path, data); err != nil {
+		return err
+	}
+	return writeFile(path+".meta", append(b, '\n'))
+}
+
+// writeFile writes data to a temporary file and renames it to path.
+func writeFile(path string, data []byte) error {
+	fp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := fp.Write(data); err != nil {
+		fp.Close()
+		os.Remove(fp.Name())
+		return err
+	}
+	if err := fp.Close(); err != nil {
+		os.Remove(fp.Name())
+		return err
+	}
+	if err := os.Chmod(fp.Name(), 0644); err != nil {
+		os.Remove(fp.Name())
+		return err
+	}
+	return os.Rename(fp.Name(), path)
+}
@@ -0,0 +1,883 @@
+// Package calendar renders GNSS calendars, i.e. calendars with GNSS weeks
+// and doy, in the layouts of the gnsscal command.
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// durations
+var oneDay time.Duration = time.Duration(time.Hour * 24)
+var oneWeek time.Duration = time.Duration(oneDay * 7)
+
+// highlight colors
+const (
+	H1 = "\033[7m%s\033[0m"   // reversed color (default)
+	H2 = "\033[4m%s\033[0m"   // underline (leap second days)
+	H3 = "\033[1m%s\033[0m"   // bold (event days)
+	H4 = "\033[100m%s\033[0m" // shaded (weeks of eclipse seasons)
+	H5 = "\033[41m%s\033[0m"  // red background (days of space weather activity)
+	H6 = "\033[44m%s\033[0m"  // blue background (other days of the GNSS week of today)
+)
+
+// Calendar is a GNSS calendar of RefDate; a month, three months, a
+// quarter, or a year according to Layout. Use NewCalendar to create one.
+type Calendar struct {
+	SatSys    gnss.SatSys
+	Highlight bool
+	RefDate   time.Time
+	Layout    Layout
+	SysTime0  time.Time
+	Today     time.Time
+	Dow       bool
+	Cell      CellFormat
+	Julian    bool
+	Columns   int
+	WholeYear bool    // year is given without month
+	LastYear  int     // last year shown with WholeYear if after the year of RefDate
+	Leap      bool    // leap second days are marked
+	Offset    bool    // offset of the system time from UTC is shown next to week numbers
+	Moscow    bool    // day boundaries follow Moscow time (GLO only)
+	Locale    *Locale // names of months and weekdays; English if nil
+	Era       bool    // Japanese era years are shown next to years in headers
+	Events    []Event // events marked in bold and listed by EventFootnote
+	Eclipse   bool    // weeks of eclipse seasons of the planes of SatSys are shaded
+	Footer    bool    // a line of the GNSS weeks, doy and days follows each month block
+	WholeWeek bool    // the other days of the GNSS week of Today are highlighted too
+	NoColor   bool    // no escape sequences are written; today is marked by brackets
+	Border    bool    // month blocks and week rows are separated by box-drawing lines
+
+	// SpaceWeather is the days of high space weather activity, colored
+	// and listed by SpaceWeatherFootnote.
+	SpaceWeather []ActiveDay
+
+	// FirstWeekday is the weekday of the first column. Rows starting on
+	// other than Sunday span two GNSS weeks, and are labeled with the GNSS
+	// week of most of their days; see rowDate.
+	FirstWeekday time.Weekday
+
+	err error // error of options, returned by NewCalendar
+}
+
+// Layout specifies the layout of a calendar
+type Layout int
+
+const (
+	Layout1Month Layout = iota
+	Layout3Month
+	Layout1Year
+	LayoutYearChart
+	LayoutQuarter
+	LayoutWeekRows
+	LayoutPlain // one labeled line per day, for screen readers and diffs
+)
+
+// CellFormat specifies the contents of day cells
+type CellFormat int
+
+const (
+	CellDay     CellFormat = iota // day of month
+	CellWeekDow                   // GNSS week and day of week, e.g. "2300/3"
+	CellBoth                      // day of month with GNSS week and day of week
+)
+
+// String returns the calendar in the text of c.Layout.
+func (c Calendar) String() string {
+	var b strings.Builder
+	c.WriteTo(&b)
+	return b.String()
+}
+
+// WriteTo writes the calendar in the text of c.Layout to w, without the
+// final newline. The years of Layout1Year, LayoutYearChart and
+// LayoutWeekRows are written while they are rendered, a row of months
+// or a week at a time, so that a long range of years (e.g. 1980-2100 with
+// WithYears) is written in bounded memory.
+func (c Calendar) WriteTo(w io.Writer) (n int64, err error) {
+	lw := &lineWriter{w: w}
+	first, last := c.years()
+	switch c.Layout {
+	case Layout1Month:
+		lw.write(c.OneMonthLayout()...)
+	case Layout3Month:
+		lw.write(c.ThreeMonthLayout()...)
+	case Layout1Year:
+		for year := first; year <= last && lw.err == nil; year++ {
+			if year > first {
+				lw.write("")
+			}
+			c.oneYear(year, lw.write)
+		}
+	case LayoutYearChart:
+		for year := first; year <= last && lw.err == nil; year++ {
+			if year > first {
+				lw.write("")
+			}
+			c.yearChart(year, lw.write)
+		}
+	case LayoutQuarter:
+		lw.write(c.QuarterLayout()...)
+	case LayoutWeekRows:
+		c.weekRowLayout(lw.write)
+	case LayoutPlain:
+		c.plainLayout(lw.write)
+	}
+
+	return lw.n, lw.err
+}
+
+// lineWriter writes lines separated by newlines. Lines written after an
+// error are discarded.
+type lineWriter struct {
+	w       io.Writer
+	n       int64
+	err     error
+	started bool
+}
+
+// write writes lines to lw.w.
+func (lw *lineWriter) write(lines ...string) {
+	for _, line := range lines {
+		if lw.err != nil {
+			return
+		}
+		if lw.started {
+			line = "\n" + line
+		}
+		lw.started = true
+		var n int
+		n, lw.err = io.WriteString(lw.w, line)
+		lw.n += int64(n)
+	}
+}
+
+// collect returns an emit function appending lines to msg.
+func collect(msg *[]string) func(lines ...string) {
+	return func(lines ...string) { *msg = append(*msg, lines...) }
+}
+
+// years returns the first and the last years shown in a whole-year
+// layout.
+func (c Calendar) years() (first, last int) {
+	first = c.RefDate.Year()
+	if c.WholeYear && c.LastYear > first {
+		return first, c.LastYear
+	}
+	return first, first
+}
+
+func (c Calendar) OneMonthLayout() (msg []string) {
+	refDate := c.RefDate
+	return c.gnssCalMonth(refDate.Year(), refDate.Month(), c.SysTime0)
+}
+
+// OneYearLayout returns the calendar of the year of c.RefDate, with
+// c.Columns months per row.
+func (c Calendar) OneYearLayout() (msg []string) {
+	c.oneYear(c.RefDate.Year(), collect(&msg))
+	return msg
+}
+
+// oneYear emits the calendar of year a row of months at a time.
+func (c Calendar) oneYear(year int, emit func(lines ...string)) {
+	// stack rows of c.Columns months
+	for m := 1; m <= 12; m += c.Columns {
+		if m > 1 {
+			emit("")
+		}
+
+		var blocks [][]string
+		for i := m; i < m+c.Columns; i++ {
+			date := time.Date(year, time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+			blocks = append(blocks, c.gnssCalMonth(date.Year(), date.Month(), c.monthEpoch(date)))
+		}
+		emit(c.joinMonths(blocks)...)
+	}
+}
+
+// YearChartLayout returns the traditional wall chart of doy for one year,
+// with months as columns and days of month as rows.
+func (c Calendar) YearChartLayout() (msg []string) {
+	c.yearChart(c.RefDate.Year(), collect(&msg))
+	return msg
+}
+
+// yearChart emits the wall chart of year a line at a time.
+func (c Calendar) yearChart(year int, emit func(lines ...string)) {
+
+	// cell width
+	w := 4 // " 001"
+	switch c.Cell {
+	case CellWeekDow:
+		w = 8 // " 2300/3"
+	case CellBoth:
+		w = 11 // " 001 2300/3"
+	}
+
+	// print header
+	head := c.withEra(fmt.Sprintf("%4d", year),
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC))
+	width := 4 + 12*w
+	emit(center(string(c.SatSys), head, width))
+	var buf strings.Builder
+	buf.Grow(width + len(H1))
+	buf.WriteString("Day ")
+	for m := time.January; m <= time.December; m++ {
+		buf.WriteString(alignRight(c.shortMonth(m), w))
+	}
+	emit(buf.String())
+
+	// print days
+	for day := 1; day <= 31; day++ {
+		buf.Reset()
+		buf.Grow(width + len(H1))
+		fmt.Fprintf(&buf, "%3d ", day)
+		for m := time.January; m <= time.December; m++ {
+			date := time.Date(year, m, day, 0, 0, 0, 0, time.UTC)
+			if date.Month() != m {
+				// no such day in the month
+				pad(&buf, w)
+				continue
+			}
+
+			var wd string
+			if !date.Before(c.SysTime0) {
+				wd = fmt.Sprintf("%d/%d", gnss.WeekNumber(date, c.SysTime0), gnss.DayOfWeek(date, c.SysTime0))
+			}
+
+			cell := fmt.Sprintf("%03d", doy(date))
+			switch c.Cell {
+			case CellWeekDow:
+				cell = wd
+			case CellBoth:
+				cell = fmt.Sprintf("%s %6s", cell, wd)
+			}
+
+			buf.WriteString(c.markCell(date, cell, w))
+		}
+		emit(buf.String())
+	}
+}
+
+func (c Calendar) ThreeMonthLayout() (msg []string) {
+	return c.threeMonthLayout(c.RefDate)
+}
+
+func (c Calendar) threeMonthLayout(refDate time.Time) (msg []string) {
+	// for three-month layout
+	lastmonth := firstDayOfLastMonth(refDate)
+	nextmonth := firstDayOfNextMonth(refDate)
+
+	msgl := c.gnssCalMonth(lastmonth.Year(), lastmonth.Month(), c.monthEpoch(lastmonth))
+	msgc := c.gnssCalMonth(refDate.Year(), refDate.Month(), c.SysTime0)
+	msgr := c.gnssCalMonth(nextmonth.Year(), nextmonth.Month(), c.monthEpoch(nextmonth))
+
+	return c.joinMonths([][]string{msgl, msgc, msgr})
+}
+
+// QuarterLayout returns three months of the calendar quarter containing c.RefDate.
+func (c Calendar) QuarterLayout() (msg []string) {
+	year := c.RefDate.Year()
+	first := (int(c.RefDate.Month())-1)/3*3 + 1
+
+	var blocks [][]string
+	for m := first; m < first+3; m++ {
+		date := time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+		blocks = append(blocks, c.gnssCalMonth(date.Year(), date.Month(), c.monthEpoch(date)))
+	}
+
+	return c.joinMonths(blocks)
+}
+
+// WeekRowLayout returns a calendar in which each row is exactly one week
+// (Sun-Sat), continuing across month boundaries. The month name is shown in
+// the margin of the row containing the first day of the month.
+// The whole year, or years up to c.LastYear, is shown if c.WholeYear is
+// true, otherwise the month of c.RefDate is shown.
+func (c Calendar) WeekRowLayout() (msg []string) {
+	c.weekRowLayout(collect(&msg))
+	return msg
+}
+
+// weekRowLayout emits the week-row calendar a week at a time.
+func (c Calendar) weekRowLayout(emit func(lines ...string)) {
+	// prepare
+	firstDay := time.Date(c.RefDate.Year(), c.RefDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDayOfNextMonth(firstDay)
+	head := c.monthYear(firstDay.Year(), firstDay.Month())
+	if c.WholeYear {
+		first, last := c.years()
+		firstDay = time.Date(first, time.January, 1, 0, 0, 0, 0, time.UTC)
+		lastDay = time.Date(last+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		head = fmt.Sprintf("%4d", first)
+		if last > first {
+			head = fmt.Sprintf("%4d-%4d", first, last)
+		}
+	}
+	head = c.withEra(head, firstDay, lastDay.Add(-oneDay))
+
+	// print header
+	margin := strings.Repeat(" ", c.marginWidth())
+	title := center(margin+string(c.SatSys), head, c.monthWidth())
+	if c.Border {
+		// boxed lines are emitted a week at a time, separated by lines
+		inner := len(margin) + c.monthWidth()
+		bar := strings.Repeat("─", inner)
+		write, sep := emit, "┌"+bar+"┐"
+		emit = func(lines ...string) {
+			boxed := []string{sep}
+			for _, line := range lines {
+				boxed = append(boxed, "│"+alignLeft(line, inner)+"│")
+			}
+			write(boxed...)
+			sep = "├" + bar + "┤"
+		}
+		defer func() { write("└" + bar + "┘") }()
+	}
+	emit(title)
+	emit(margin + c.weekHeader())
+
+	// print weeks
+	var rows weekRows
+	start := c.weekStart(firstDay)
+	for ; start.Before(lastDay); start = start.Add(oneWeek) {
+		label := c.rowDate(start, start.Add(6*oneDay))
+
+		// month name in the margin
+		name := margin
+		for date := start; date.Before(start.Add(oneWeek)); date = date.Add(oneDay) {
+			if date.Day() == 1 {
+				name = alignLeft(c.shortMonth(date.Month()), len(margin))
+			}
+		}
+
+		rows.reset(len(margin) + c.monthWidth())
+		rows.add(name+c.weekCell(label, c.monthEpoch(label)), strings.Repeat(" ", len(margin)+c.weekWidth()),
+			strings.Repeat(" ", len(margin)+c.weekWidth()), fmt.Sprintf("%*s  ", len(margin)+c.weekWidth()-2, "dow"))
+		for date := start; date.Before(start.Add(oneWeek)); date = date.Add(oneDay) {
+			rows.add(c.dayCells(date, c.monthEpoch(date)))
+		}
+		emit(c.appendWeekRows(nil, &rows)...)
+	}
+}
+
+// column returns the column of date in a week row, 0 for c.FirstWeekday.
+func (c Calendar) column(date time.Time) int {
+	return int(date.Weekday()-c.FirstWeekday+7) % 7
+}
+
+// rowDate returns the day of which the GNSS week labels the row of the
+// days from first to last shown. Rows starting on Sunday, as GNSS weeks
+// do, are labeled with the week of the first day; other rows, spanning
+// two GNSS weeks, with the week of their middle day, i.e. of most of
+// their days.
+func (c Calendar) rowDate(first, last time.Time) time.Time {
+	if c.FirstWeekday == time.Sunday {
+		return first
+	}
+	return first.Add(time.Duration(gnss.DaysBetween(first, last)/2) * oneDay)
+}
+
+// weekStart returns the first day of the week row of date.
+func (c Calendar) weekStart(date time.Time) time.Time {
+	return date.Add(-time.Duration(c.column(date)) * oneDay)
+}
+
+// PlainLayout returns one line per day of the month of c.RefDate, or of
+// the year with c.WholeYear, labeled without a grid, e.g.
+//
+//	2024-03-15, Friday, DOY 075, GPS week 2305 day 5
+//
+// The line of today ends with ", today" if c.Highlight is true, those of
+// leap second days with ", leap second" if c.Leap is true, those of days
+// of c.Events with the names of the events, and those in eclipse seasons
+// with the planes if c.Eclipse is true, and those of c.SpaceWeather with
+// their indices.
+func (c Calendar) PlainLayout() (msg []string) {
+	c.plainLayout(collect(&msg))
+	return msg
+}
+
+// plainLayout emits the plain layout a day at a time.
+func (c Calendar) plainLayout(emit func(lines ...string)) {
+	first, last := c.Period()
+	var b strings.Builder
+	for date := first; date.Before(last); date = date.Add(oneDay) {
+		b.Reset()
+		fmt.Fprintf(&b, "%s, %s, DOY %03d", date.Format("2006-01-02"), date.Weekday(), doy(date))
+		if initialDate := c.monthEpoch(date); !date.Before(initialDate) {
+			fmt.Fprintf(&b, ", %s week %d day %d", c.SatSys, gnss.WeekNumber(date, initialDate), gnss.DayOfWeek(date, initialDate))
+		}
+		if date.Equal(c.Today) && c.Highlight {
+			b.WriteString(", today")
+		} else if c.inTodayWeek(date) {
+			b.WriteString(", week of today")
+		}
+		if c.Leap && isLeapSecondDay(date) {
+			b.WriteString(", leap second")
+		}
+		for _, name := range c.eventsOf(date) {
+			b.WriteString(", " + name)
+		}
+		if planes := c.eclipsePlanes(date); c.Eclipse && len(planes) == 1 {
+			fmt.Fprintf(&b, ", eclipse season of plane %s", planes[0])
+		} else if c.Eclipse && len(planes) > 1 {
+			fmt.Fprintf(&b, ", eclipse season of planes %s", strings.Join(planes, " and "))
+		}
+		if d, ok := c.activeDay(date); ok {
+			b.WriteString(", " + d.Label)
+		}
+		emit(b.String())
+	}
+}
+
+// monthEpoch returns the initial date of GNSS week applied to the month of date.
+func (c Calendar) monthEpoch(date time.Time) time.Time {
+	if info, ok := gnss.Lookup(string(c.SatSys)); ok && info.EpochFn != nil {
+		return info.EpochFn(date) // Glonass
+	}
+	return c.SysTime0
+}
+
+// joinMonths places month blocks side by side.
+func (c Calendar) joinMonths(blocks [][]string) (msg []string) {
+	// check number of lines
+	N := 0
+	for _, b := range blocks {
+		if len(b) > N {
+			N = len(b)
+		}
+	}
+
+	w := c.monthWidth()
+	if c.Border {
+		w += 2 // "│" on both sides
+	}
+	msg = make([]string, 0, N)
+	var buf strings.Builder
+	for i := 0; i < N; i++ {
+		buf.Reset()
+		buf.Grow(len(blocks) * (w + 4 + len(H1)))
+		for j, b := range blocks {
+			if j > 0 {
+				buf.WriteString("    ")
+			}
+			if len(b) > i {
+				buf.WriteString(b[i])
+				pad(&buf, w-displayWidth(b[i]))
+			} else {
+				pad(&buf, w)
+			}
+		}
+		msg = append(msg, buf.String())
+	}
+
+	return
+}
+
+// pad writes n spaces to b.
+func pad(b *strings.Builder, n int) {
+	for ; n > 0; n-- {
+		b.WriteByte(' ')
+	}
+}
+
+// marginWidth returns the width of the margin of month names in the
+// week-row layout, a column wider than the names.
+func (c Calendar) marginWidth() int {
+	w := 0
+	for _, name := range c.locale().ShortMonths {
+		w = max(w, displayWidth(name))
+	}
+	return w + 1
+}
+
+// cellWidth returns the width of a day cell.
+func (c Calendar) cellWidth() int {
+	if c.Cell == CellDay {
+		return 4
+	}
+	return 7 // " 2300/3"
+}
+
+// weekWidth returns the width of the column of week numbers.
+func (c Calendar) weekWidth() int {
+	if c.Offset {
+		return 11 // "2300  +18s "
+	}
+	return 6 // "2300  "
+}
+
+// monthWidth returns the width of a month block.
+func (c Calendar) monthWidth() int {
+	return c.weekWidth() + 7*c.cellWidth()
+}
+
+// weekCell returns the cell of the week number for the row starting at date.
+// If c.Offset is true, the offset of the system time from UTC at date is
+// appended.
+func (c Calendar) weekCell(date, initialDate time.Time) string {
+	week := ""
+	if !date.Before(initialDate) {
+		week = strconv.Itoa(gnss.WeekNumber(date, initialDate))
+	}
+	week = fmt.Sprintf("%4s", week)
+	if c.Eclipse && !c.NoColor && c.isEclipseWeek(date, initialDate) {
+		week = fmt.Sprintf(H4, week)
+	}
+	if !c.Offset {
+		return week + "  "
+	}
+
+	info, _ := gnss.Lookup(string(c.SatSys))
+	off := info.UTCOffset(date)
+	offset := fmt.Sprintf("%+ds", int(off/time.Second))
+	if off%time.Hour == 0 && off != 0 {
+		offset = fmt.Sprintf("%+dh", int(off/time.Hour))
+	}
+	return fmt.Sprintf("%s %5s ", week, offset)
+}
+
+// gnssCalMonth returns calendar msg for a month, the text of MonthGrid.
+//
+// 'year', 'month' specify the month to be shown.
+// If c.Highlight is true, c.Today is highlighted.
+// GNSS week is calculated based on the 'initialDate'.
+// If c.Dow is true, GNSS day of week is shown under the doy of each day.
+// c.Cell specifies whether the day of month and/or GNSS week/dow are shown.
+// If c.Julian is true, the doy is shown instead of the day of month.
+//
+// Note that the initialDate may not start from Sunday for GLONASS.
+// So the week numbers are calculated at first day of the month and of
+// each week row, and the same week numbers could be printed.
+func (c Calendar) gnssCalMonth(year int, month time.Month, initialDate time.Time) (msg []string) {
+	var rows weekRows
+	w := c.cellWidth()
+	blank := strings.Repeat(" ", w)
+
+	// prepare
+	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDayOfNextMonth(firstDay)
+
+	// print header; up to 6 weeks of 4 rows follow
+	msg = make([]string, 0, 2+6*4)
+	head := c.withEra(c.monthYear(year, month), firstDay, lastDay.Add(-oneDay))
+	msg = append(msg, center(string(c.SatSys), head, c.monthWidth()))
+	msg = append(msg, c.weekHeader())
+
+	// print dates; the days of the next month are not filled
+	weeks := c.MonthGrid(year, month).Weeks
+	var groups [][]string // lines of the weeks with c.Border
+	for _, wk := range weeks {
+		rows.reset(c.monthWidth())
+		rows.add(c.weekCell(wk.label, initialDate), strings.Repeat(" ", c.weekWidth()),
+			strings.Repeat(" ", c.weekWidth()), fmt.Sprintf("%*s  ", c.weekWidth()-2, "dow"))
+		for _, cell := range wk.Cells {
+			switch {
+			case cell.InMonth:
+				rows.add(c.dayCells(cell.Date, initialDate))
+			case cell.Date.Before(firstDay):
+				rows.add(blank, blank, blank, blank)
+			}
+		}
+		if c.Border {
+			groups = append(groups, c.appendWeekRows(nil, &rows))
+			continue
+		}
+		msg = c.appendWeekRows(msg, &rows)
+	}
+
+	if c.Border {
+		return c.borderedMonth(msg, groups, firstDay, lastDay.Add(-oneDay), initialDate)
+	}
+
+	if c.Footer {
+		// footers of months side by side are aligned below 6 weeks
+		if c.Layout != Layout1Month && len(weeks) > 0 {
+			n := (len(msg) - 2) / len(weeks) // lines per week
+			for i := len(weeks) * n; i < 6*n; i++ {
+				msg = append(msg, "")
+			}
+		}
+		msg = append(msg, c.monthFooter(firstDay, lastDay.Add(-oneDay), initialDate))
+	}
+
+	return
+}
+
+// borderedMonth returns the month block of the header lines head and the
+// lines of weeks in a box of box-drawing characters, with lines separating
+// the header, the weeks, and the footer if c.Footer is true. Boxes of
+// months side by side have 6 weeks to be aligned.
+func (c Calendar) borderedMonth(head []string, weeks [][]string, first, last, initialDate time.Time) []string {
+	groups := [][]string{head[:1], head[1:]}
+	groups = append(groups, weeks...)
+	if c.Layout != Layout1Month && len(weeks) > 0 {
+		for i := len(weeks); i < 6; i++ {
+			groups = append(groups, make([]string, len(weeks[0])))
+		}
+	}
+	if c.Footer {
+		groups = append(groups, []string{c.monthFooter(first, last, initialDate)})
+	}
+	return boxed(groups, c.monthWidth())
+}
+
+// boxed returns the lines of groups in a box of the inner width w drawn
+// with box-drawing characters, with the groups separated by lines.
+func boxed(groups [][]string, w int) []string {
+	bar := strings.Repeat("─", w)
+	msg := []string{"┌" + bar + "┐"}
+	for i, g := range groups {
+		if i > 0 {
+			msg = append(msg, "├"+bar+"┤")
+		}
+		for _, line := range g {
+			msg = append(msg, "│"+alignLeft(line, w)+"│")
+		}
+	}
+	return append(msg, "└"+bar+"┘")
+}
+
+// monthFooter returns the footer line of the month from first to last,
+// e.g. "W2303-2308 DOY 061-091 31 days"; the weeks are of the days after
+// initialDate.
+func (c Calendar) monthFooter(first, last, initialDate time.Time) string {
+	var b strings.Builder
+	if !last.Before(initialDate) {
+		week := 0
+		if !first.Before(initialDate) {
+			week = gnss.WeekNumber(first, initialDate)
+		}
+		fmt.Fprintf(&b, "W%04d-%04d ", week, gnss.WeekNumber(last, initialDate))
+	}
+	fmt.Fprintf(&b, "DOY %03d-%03d %d days", doy(first), doy(last), gnss.DaysBetween(first, last)+1)
+	return b.String()
+}
+
+// weekHeader returns the header line of week number and weekdays.
+func (c Calendar) weekHeader() string {
+	var header strings.Builder
+	header.Grow(c.monthWidth())
+	if c.Offset {
+		fmt.Fprintf(&header, "%-6s%-*s", "Week", c.weekWidth()-6, "-UTC")
+	} else {
+		fmt.Fprintf(&header, "%-*s", c.weekWidth(), "Week")
+	}
+	for i := 0; i < 7; i++ {
+		name := c.weekdayName((c.FirstWeekday + time.Weekday(i)) % 7)
+		header.WriteString(alignRight(name, c.cellWidth()))
+	}
+	return header.String()
+}
+
+// dayCells returns the cells of a day for the rows of day of month,
+// doy, GNSS week/dow, and GNSS day of week.
+func (c Calendar) dayCells(date, initialDate time.Time) (day, yday, wd, dow string) {
+	w := c.cellWidth()
+
+	// GNSS week/dow
+	if !date.Before(initialDate) {
+		wd = fmt.Sprintf("%d/%d", gnss.WeekNumber(date, initialDate), gnss.DayOfWeek(date, initialDate))
+		dow = fmt.Sprintf("%d", gnss.DayOfWeek(date, initialDate))
+	}
+
+	day = fmt.Sprintf("%2d", date.Day())
+	if c.Julian {
+		day = fmt.Sprintf("%03d", doy(date))
+	}
+	if c.Cell == CellWeekDow {
+		day = wd
+	}
+	day = c.markCell(date, day, w)
+	yday = fmt.Sprintf("%*s", w, fmt.Sprintf("%03d", doy(date)))
+	wd = fmt.Sprintf("%*s", w, wd)
+	dow = fmt.Sprintf("%*s", w, dow)
+
+	return
+}
+
+// markCell returns the cell of date right-aligned in the width w.
+// Today is highlighted if c.Highlight is true, with the other days of its
+// GNSS week in blue if c.WholeWeek is also true, leap second days are
+// underlined if c.Leap is true, days of c.Events are in bold, and days of
+// c.SpaceWeather are in red. If c.NoColor is true, only today is marked,
+// by brackets or an asterisk; see todayMarker.
+func (c Calendar) markCell(date time.Time, cell string, w int) string {
+	if c.NoColor {
+		if date.Equal(c.Today) && c.Highlight {
+			cell = todayMarker(cell, w)
+		}
+		return fmt.Sprintf("%*s", w, cell)
+	}
+
+	switch {
+	case date.Equal(c.Today) && c.Highlight:
+		return fmt.Sprintf("%*s"+H1, w-len(cell), "", cell) // reversed color
+	case c.inTodayWeek(date):
+		return fmt.Sprintf("%*s"+H6, w-len(cell), "", cell) // blue background
+	case c.Leap && isLeapSecondDay(date):
+		return fmt.Sprintf("%*s"+H2, w-len(cell), "", cell) // underline
+	case len(c.eventsOf(date)) > 0:
+		return fmt.Sprintf("%*s"+H3, w-len(cell), "", cell) // bold
+	case c.SpaceWeather != nil:
+		if _, ok := c.activeDay(date); ok {
+			return fmt.Sprintf("%*s"+H5, w-len(cell), "", cell) // red background
+		}
+	}
+	return fmt.Sprintf("%*s", w, cell)
+}
+
+// todayMarker returns the cell of today marked without escape sequences
+// in the width w; in brackets, e.g. "[15]", or with an asterisk, e.g.
+// "*073", if the brackets do not fit.
+func todayMarker(cell string, w int) string {
+	switch {
+	case len(cell)+2 <= w:
+		return "[" + cell + "]"
+	case len(cell)+1 <= w:
+		return "*" + cell
+	}
+	return cell
+}
+
+// inTodayWeek reports whether date is in the GNSS week of c.Today, of which
+// the days are highlighted if c.WholeWeek and c.Highlight are true.
+func (c Calendar) inTodayWeek(date time.Time) bool {
+	if !c.WholeWeek || !c.Highlight {
+		return false
+	}
+	initialDate := c.monthEpoch(date)
+	return initialDate.Equal(c.monthEpoch(c.Today)) && gnss.WeekNumber(date, initialDate) == gnss.WeekNumber(c.Today, initialDate)
+}
+
+// Period returns the first day and the day after the last day shown in c.
+func (c Calendar) Period() (first, last time.Time) {
+	first, last = c.months()
+	if c.Layout == LayoutWeekRows {
+		// rows are extended to whole weeks
+		first = c.weekStart(first)
+		last = last.Add(time.Duration((7-c.column(last))%7) * oneDay)
+	}
+	return first, last
+}
+
+// months returns the first day of the months shown in c and the first day
+// of the month after them.
+func (c Calendar) months() (first, last time.Time) {
+	year, month := c.RefDate.Year(), c.RefDate.Month()
+	first = time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	switch c.Layout {
+	case Layout3Month:
+		first = firstDayOfLastMonth(first)
+		last = first.AddDate(0, 3, 0)
+	case Layout1Year, LayoutYearChart:
+		_, lastYear := c.years()
+		first = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		last = time.Date(lastYear+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case LayoutQuarter:
+		first = time.Date(year, (month-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+		last = first.AddDate(0, 3, 0)
+	case LayoutWeekRows, LayoutPlain:
+		last = firstDayOfNextMonth(first)
+		if c.WholeYear {
+			_, lastYear := c.years()
+			first = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			last = time.Date(lastYear+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+	default:
+		last = firstDayOfNextMonth(first)
+	}
+	return first, last
+}
+
+// LeapFootnote returns the footnote listing leap seconds in the period shown.
+func (c Calendar) LeapFootnote() (msg []string) {
+	first, last := c.Period()
+	for _, l := range gnss.LeapSeconds() {
+		if l.Date.Before(first) || !l.Date.Before(last) {
+			continue
+		}
+		msg = append(msg, fmt.Sprintf("leap second at %s 23:59:60 UTC (doy %03d); TAI-UTC %d s, GPS-UTC %d s",
+			l.Date.Format("2006-01-02"), doy(l.Date), int(l.TAIMinusUTC.Seconds()), int(l.TAIMinusUTC.Seconds())-19))
+	}
+	if len(msg) == 0 {
+		msg = append(msg, "no leap second in this period")
+	}
+	return msg
+}
+
+// isLeapSecondDay reports whether a leap second is inserted at the end of date.
+func isLeapSecondDay(date time.Time) bool {
+	for _, l := range gnss.LeapSeconds() {
+		if l.Date.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekRows are the rows of a week being built: day of month, doy, GNSS
+// week/dow, and GNSS day of week.
+type weekRows struct {
+	day, doy, wd, dow strings.Builder
+}
+
+// reset empties the rows and preallocates n bytes for each, plus the
+// escape sequences of a highlighted cell.
+func (r *weekRows) reset(n int) {
+	for _, b := range []*strings.Builder{&r.day, &r.doy, &r.wd, &r.dow} {
+		b.Reset()
+		b.Grow(n + len(H1))
+	}
+}
+
+// add appends the cells of a day to the rows.
+func (r *weekRows) add(day, doy, wd, dow string) {
+	r.day.WriteString(day)
+	r.doy.WriteString(doy)
+	r.wd.WriteString(wd)
+	r.dow.WriteString(dow)
+}
+
+// appendWeekRows appends the rows of a week to be shown to msg.
+func (c Calendar) appendWeekRows(msg []string, r *weekRows) []string {
+	msg = append(msg, r.day.String())
+	if !c.Julian || c.Cell == CellWeekDow {
+		msg = append(msg, r.doy.String())
+	}
+	if c.Cell == CellBoth {
+		msg = append(msg, r.wd.String())
+	}
+	if c.Dow {
+		msg = append(msg, r.dow.String())
+	}
+	return msg
+}
+
+func firstDayOfNextMonth(date time.Time) time.Time {
+	if date.Month() == time.December {
+		return time.Date(date.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	} else {
+		return time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func firstDayOfLastMonth(date time.Time) time.Time {
+	if date.Month() == time.January {
+		return time.Date(date.Year()-1, time.December, 1, 0, 0, 0, 0, time.UTC)
+	} else {
+		return time.Date(date.Year(), date.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func doy(date time.Time) int {
+	return date.YearDay()
+}
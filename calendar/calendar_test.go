@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+func TestNewCalendarError(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want error
+	}{
+		{"month 0", []Option{WithMonth(2024, 0)}, ErrInvalidMonth},
+		{"month 13", []Option{WithMonth(2024, 13)}, ErrInvalidMonth},
+		{"year before 1980", []Option{WithYear(1979)}, ErrInvalidYear},
+		{"year after 9999", []Option{WithYear(10000)}, ErrInvalidYear},
+		{"reversed years", []Option{WithYears(2024, 2023)}, ErrInvalidYear},
+		{"last year after 9999", []Option{WithYears(2024, 10000)}, ErrInvalidYear},
+		{"reference before 1980", []Option{WithReference(date(1979, time.December, 31))}, ErrInvalidYear},
+		{"columns 0", []Option{WithColumns(0)}, ErrInvalidColumns},
+		{"columns 5", []Option{WithColumns(5)}, ErrInvalidColumns},
+		{"weekday -1", []Option{WithFirstWeekday(-1)}, ErrInvalidWeekday},
+		{"weekday 7", []Option{WithFirstWeekday(7)}, ErrInvalidWeekday},
+		{"Moscow of GPS", []Option{WithMoscow(true)}, ErrMoscowNotGLO},
+		{"Moscow of GAL", []Option{WithSatSys(gnss.SYSGAL), WithMoscow(true)}, ErrMoscowNotGLO},
+		{"unknown system", []Option{WithSatSys("FOO")}, gnss.ErrUnknownSatSys},
+	}
+	for _, tt := range tests {
+		if _, err := NewCalendar(tt.opts...); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	for _, n := range []int{1, 2, 3, 4, 6, 12} {
+		if _, err := NewCalendar(WithColumns(n)); err != nil {
+			t.Errorf("columns %d: %v", n, err)
+		}
+	}
+	if _, err := NewCalendar(WithSatSys(gnss.SYSGLO), WithMoscow(true)); err != nil {
+		t.Errorf("Moscow of GLO: %v", err)
+	}
+}
+
+func TestNewCalendarOptionOrder(t *testing.T) {
+	today := date(2024, time.May, 2)
+	tests := []struct {
+		name      string
+		opts      []Option
+		layout    Layout
+		ref       time.Time
+		wholeYear bool
+		lastYear  int
+	}{
+		{"default", nil, Layout1Month, today, false, 0},
+		{"year", []Option{WithYear(2023)}, Layout1Year, date(2023, time.January, 1), true, 0},
+		{"year then layout", []Option{WithYear(2023), WithLayout(LayoutWeekRows)}, LayoutWeekRows, date(2023, time.January, 1), true, 0},
+		{"layout then year", []Option{WithLayout(LayoutWeekRows), WithYear(2023)}, Layout1Year, date(2023, time.January, 1), true, 0},
+		{"years", []Option{WithYears(1980, 2100)}, Layout1Year, date(1980, time.January, 1), true, 2100},
+		{"year then reference", []Option{WithYears(2023, 2025), WithReference(date(2024, time.March, 14))}, Layout1Year, date(2024, time.March, 14), false, 0},
+		{"reference then year", []Option{WithReference(date(2024, time.March, 14)), WithYear(2023)}, Layout1Year, date(2023, time.January, 1), true, 0},
+		{"month", []Option{WithMonth(2024, time.March)}, Layout1Month, date(2024, time.March, 1), false, 0},
+	}
+	for _, tt := range tests {
+		c, err := NewCalendar(append([]Option{WithToday(today)}, tt.opts...)...)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if c.Layout != tt.layout || !c.RefDate.Equal(tt.ref) || c.WholeYear != tt.wholeYear || c.LastYear != tt.lastYear {
+			t.Errorf("%s: layout %d, reference %s, whole year %v, last year %d; want %d, %s, %v, %d",
+				tt.name, c.Layout, c.RefDate.Format("2006-01-02"), c.WholeYear, c.LastYear,
+				tt.layout, tt.ref.Format("2006-01-02"), tt.wholeYear, tt.lastYear)
+		}
+	}
+}
+
+func BenchmarkThreeMonthLayout(b *testing.B) {
+	c, err := NewCalendar(
+		WithMonth(2024, time.May),
+		WithLayout(Layout3Month),
+		WithToday(date(2024, time.May, 2)),
+		WithCell(CellBoth),
+		WithDow(true),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		c.ThreeMonthLayout()
+	}
+}
+
+func BenchmarkWriteToYears(b *testing.B) {
+	c, err := NewCalendar(WithYears(1980, 2100), WithToday(date(2024, time.May, 2)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := c.WriteTo(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// eclipsePlanes returns the names of the planes of c.SatSys in an eclipse
+// season on date.
+func (c Calendar) eclipsePlanes(date time.Time) (names []string) {
+	for _, p := range gnss.OrbitPlanes(c.SatSys) {
+		if p.InEclipseSeason(date) {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// isEclipseWeek reports whether a day of the GNSS week of date is in an
+// eclipse season of a plane of c.SatSys.
+func (c Calendar) isEclipseWeek(date, initialDate time.Time) bool {
+	start := date
+	if !date.Before(initialDate) {
+		start = date.Add(-time.Duration(gnss.DayOfWeek(date, initialDate)) * oneDay)
+	}
+	r := gnss.DateRange{Start: start, End: start.Add(6 * oneDay)}
+	return len(gnss.EclipseSeasons(c.SatSys, r)) > 0
+}
+
+// EclipseFootnote returns the footnote listing the eclipse seasons of the
+// planes of c.SatSys in the period shown, computed from the nominal
+// planes and approximate by a few days; see gnss.EclipseSeasons.
+func (c Calendar) EclipseFootnote() (msg []string) {
+	if len(gnss.OrbitPlanes(c.SatSys)) == 0 {
+		return []string{fmt.Sprintf("no eclipse seasons of %s are modeled; only those of GPS and GAL", c.SatSys)}
+	}
+
+	// seasons are searched beyond the period so as not to be clipped
+	first, last := c.Period()
+	period := gnss.DateRange{Start: first, End: last.Add(-oneDay)}
+	for _, s := range gnss.EclipseSeasons(c.SatSys, gnss.DateRange{Start: first.AddDate(0, 0, -90), End: last.AddDate(0, 0, 90)}) {
+		if !s.Overlaps(period) {
+			continue
+		}
+		weeks := ""
+		if initialDate := c.monthEpoch(s.Start); !s.Start.Before(initialDate) {
+			weeks = fmt.Sprintf(" (%s weeks %d-%d)", c.SatSys, gnss.WeekNumber(s.Start, initialDate), gnss.WeekNumber(s.End, initialDate))
+		}
+		msg = append(msg, fmt.Sprintf("eclipse season of %s plane %s: %s to %s%s",
+			c.SatSys, s.Plane.Name, s.Start.Format("2006-01-02"), s.End.Format("2006-01-02"), weeks))
+	}
+	if len(msg) == 0 {
+		msg = append(msg, "no eclipse season in this period")
+	}
+	return msg
+}
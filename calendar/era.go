@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// japaneseEras are the Japanese eras from Showa, in order.
+var japaneseEras = []struct {
+	name  string
+	start time.Time
+}{
+	{"昭和", time.Date(1926, time.December, 25, 0, 0, 0, 0, time.UTC)},
+	{"平成", time.Date(1989, time.January, 8, 0, 0, 0, 0, time.UTC)},
+	{"令和", time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+// japaneseEra returns the Japanese era years of the dates from first to
+// last, e.g. "令和6" for 2024, "令和2-6" for 2020-2024, and "平成31/令和元"
+// for 2019. The first year of an era is written as 元 (gannen).
+func japaneseEra(first, last time.Time) string {
+	var parts []string
+	for i, era := range japaneseEras {
+		end := last.Add(oneDay)
+		if i+1 < len(japaneseEras) {
+			end = japaneseEras[i+1].start
+		}
+		if !first.Before(end) || last.Before(era.start) {
+			continue
+		}
+
+		from, to := first, last
+		if from.Before(era.start) {
+			from = era.start
+		}
+		if !to.Before(end) {
+			to = end.Add(-oneDay)
+		}
+		part := era.name + eraYear(from.Year()-era.start.Year()+1)
+		if to.Year() > from.Year() {
+			part += "-" + eraYear(to.Year()-era.start.Year()+1)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// eraYear returns the year of an era, 元 for the first.
+func eraYear(n int) string {
+	if n == 1 {
+		return "元"
+	}
+	return strconv.Itoa(n)
+}
+
+// withEra appends the Japanese era years of the dates from first to last
+// to head if c.Era is set.
+func (c Calendar) withEra(head string, first, last time.Time) string {
+	if !c.Era {
+		return head
+	}
+	return head + " (" + japaneseEra(first, last) + ")"
+}
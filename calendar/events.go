@@ -0,0 +1,72 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// Event is a notable date of GNSS, e.g. a week rollover, marked in
+// calendars with WithEvents.
+type Event struct {
+	Date time.Time // 00:00 UTC
+	Name string
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// gnssEvents is the database of GNSS events, sorted by date.
+var gnssEvents = []Event{
+	{date(1980, time.January, 6), "GPS time epoch (GPS week 0)"},
+	{date(1993, time.December, 8), "GPS initial operational capability"},
+	{date(1995, time.July, 17), "GPS full operational capability"},
+	{date(1999, time.August, 22), "GPS week rollover, 10-bit week 1023 to 0 (week 1024)"},
+	{date(1999, time.August, 22), "Galileo system time (GST) and NavIC time epoch"},
+	{date(2000, time.May, 2), "GPS Selective Availability turned off"},
+	{date(2006, time.January, 1), "BeiDou time (BDT) epoch"},
+	{date(2012, time.December, 27), "BeiDou-2 regional service"},
+	{date(2016, time.December, 15), "Galileo initial services"},
+	{date(2018, time.November, 1), "QZSS four-satellite service"},
+	{date(2019, time.April, 7), "GPS week rollover, 10-bit week 1023 to 0 (week 2048)"},
+	{date(2020, time.July, 31), "BeiDou-3 global service"},
+	{date(2038, time.November, 21), "GPS week rollover, 10-bit week 1023 to 0 (week 3072)"},
+}
+
+// GNSSEvents returns the built-in events: the epochs and week rollovers of
+// the systems, and the declarations of their services.
+func GNSSEvents() []Event {
+	return append([]Event(nil), gnssEvents...)
+}
+
+// eventsOf returns the names of the events of c on date.
+func (c Calendar) eventsOf(date time.Time) (names []string) {
+	for _, e := range c.Events {
+		if e.Date.Equal(date) {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+// EventFootnote returns the footnote listing the events of c in the
+// period shown, with their doy and GNSS week of c.SatSys.
+func (c Calendar) EventFootnote() (msg []string) {
+	first, last := c.Period()
+	for _, e := range c.Events {
+		if e.Date.Before(first) || !e.Date.Before(last) {
+			continue
+		}
+		week := ""
+		if initialDate := c.monthEpoch(e.Date); !e.Date.Before(initialDate) {
+			week = fmt.Sprintf(", %s week %d", c.SatSys, gnss.WeekNumber(e.Date, initialDate))
+		}
+		msg = append(msg, fmt.Sprintf("%s (doy %03d%s): %s", e.Date.Format("2006-01-02"), doy(e.Date), week, e.Name))
+	}
+	if len(msg) == 0 {
+		msg = append(msg, "no GNSS event in this period")
+	}
+	return msg
+}
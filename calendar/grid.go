@@ -0,0 +1,106 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// MonthGrid is a month of a calendar as data, laid out in rows of weeks
+// as the text layouts are, for frontends rendering their own views.
+type MonthGrid struct {
+	SatSys gnss.SatSys `json:"satsys"`
+	Year   int         `json:"year"`
+	Month  time.Month  `json:"month"`
+	Weeks  []GridWeek  `json:"weeks"`
+}
+
+// GridWeek is a row of a MonthGrid, of seven days from the FirstWeekday
+// of the calendar.
+type GridWeek struct {
+	Week  int         `json:"week"` // GNSS week labeling the row (see FirstWeekday), or -1
+	Cells [7]GridCell `json:"cells"`
+
+	label time.Time // day of which the week labels the row
+}
+
+// GridCell is a day of a GridWeek. Days of the previous and the next
+// months filling the first and the last rows have InMonth false.
+type GridCell struct {
+	Date       time.Time `json:"date"` // 00:00 UTC
+	Doy        int       `json:"doy"`
+	Week       int       `json:"week"` // GNSS week, or -1 before the epoch of the system
+	Dow        int       `json:"dow"`  // GNSS day of week, or -1 before the epoch of the system
+	InMonth    bool      `json:"in_month"`
+	Today      bool      `json:"today"`
+	TodayWeek  bool      `json:"today_week,omitempty"` // in the GNSS week of today (WithWholeWeek)
+	LeapSecond bool      `json:"leap_second"`          // a leap second is inserted at the end of the day
+	Events     []string  `json:"events,omitempty"`     // names of the events of the calendar (WithEvents)
+	Eclipse    []string  `json:"eclipse,omitempty"`    // planes in an eclipse season (WithEclipse)
+	Activity   string    `json:"activity,omitempty"`   // label of a day of space weather activity (WithSpaceWeather)
+}
+
+// MonthGrid returns the grid of month of year with the weeks of c.SatSys
+// starting on c.FirstWeekday.
+func (c Calendar) MonthGrid(year int, month time.Month) MonthGrid {
+	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDayOfNextMonth(firstDay).Add(-oneDay)
+	g := MonthGrid{SatSys: c.SatSys, Year: year, Month: month}
+
+	for start := c.weekStart(firstDay); !start.After(lastDay); start = start.Add(oneWeek) {
+		// the row is labeled by the days of the month in it
+		first, last := start, start.Add(6*oneDay)
+		if first.Before(firstDay) {
+			first = firstDay
+		}
+		if last.After(lastDay) {
+			last = lastDay
+		}
+
+		wk := GridWeek{Week: -1, label: c.rowDate(first, last)}
+		if initialDate := c.monthEpoch(wk.label); !wk.label.Before(initialDate) {
+			wk.Week = gnss.WeekNumber(wk.label, initialDate)
+		}
+		for i := range wk.Cells {
+			wk.Cells[i] = c.gridCell(start.Add(time.Duration(i)*oneDay), firstDay, lastDay)
+		}
+		g.Weeks = append(g.Weeks, wk)
+	}
+	return g
+}
+
+// gridCell returns the cell of date in the month from firstDay to lastDay.
+func (c Calendar) gridCell(date, firstDay, lastDay time.Time) GridCell {
+	cell := GridCell{
+		Date:       date,
+		Doy:        doy(date),
+		Week:       -1,
+		Dow:        -1,
+		InMonth:    !date.Before(firstDay) && !date.After(lastDay),
+		Today:      date.Equal(c.Today),
+		TodayWeek:  c.inTodayWeek(date),
+		LeapSecond: isLeapSecondDay(date),
+		Events:     c.eventsOf(date),
+	}
+	if c.Eclipse {
+		cell.Eclipse = c.eclipsePlanes(date)
+	}
+	if d, ok := c.activeDay(date); ok {
+		cell.Activity = d.Label
+	}
+	if initialDate := c.monthEpoch(date); !date.Before(initialDate) {
+		cell.Week, cell.Dow = gnss.WeekNumber(date, initialDate), gnss.DayOfWeek(date, initialDate)
+	}
+	return cell
+}
+
+// MonthGrids returns the grids of the months shown in c, including the
+// months of a range of years given by WithYears.
+func (c Calendar) MonthGrids() []MonthGrid {
+	first, last := c.months()
+	var gs []MonthGrid
+	for m := first; m.Before(last); m = firstDayOfNextMonth(m) {
+		gs = append(gs, c.MonthGrid(m.Year(), m.Month()))
+	}
+	return gs
+}
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+func TestMonthGrid(t *testing.T) {
+	c, err := NewCalendar(WithMonth(2024, time.May), WithToday(date(2024, time.May, 2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := c.MonthGrid(2024, time.May)
+	if g.SatSys != gnss.SYSGPS || g.Year != 2024 || g.Month != time.May || len(g.Weeks) != 5 {
+		t.Fatalf("grid of %s %d-%02d with %d weeks, want GPS 2024-05 with 5", g.SatSys, g.Year, g.Month, len(g.Weeks))
+	}
+	for i, wk := range g.Weeks {
+		if want := 2312 + i; wk.Week != want {
+			t.Errorf("week of row %d = %d, want %d", i, wk.Week, want)
+		}
+	}
+
+	// 2024-05-01 is Wednesday; the first row starts on Sunday, April 28
+	tests := []struct {
+		row, col int
+		want     GridCell
+	}{
+		{0, 0, GridCell{Date: date(2024, time.April, 28), Doy: 119, Week: 2312, Dow: 0}},
+		{0, 3, GridCell{Date: date(2024, time.May, 1), Doy: 122, Week: 2312, Dow: 3, InMonth: true}},
+		{0, 4, GridCell{Date: date(2024, time.May, 2), Doy: 123, Week: 2312, Dow: 4, InMonth: true, Today: true}},
+		{4, 5, GridCell{Date: date(2024, time.May, 31), Doy: 152, Week: 2316, Dow: 5, InMonth: true}},
+		{4, 6, GridCell{Date: date(2024, time.June, 1), Doy: 153, Week: 2316, Dow: 6}},
+	}
+	for _, tt := range tests {
+		got := g.Weeks[tt.row].Cells[tt.col]
+		if !got.Date.Equal(tt.want.Date) || got.Doy != tt.want.Doy || got.Week != tt.want.Week || got.Dow != tt.want.Dow || got.InMonth != tt.want.InMonth || got.Today != tt.want.Today {
+			t.Errorf("cell %d,%d = %+v, want %+v", tt.row, tt.col, got, tt.want)
+		}
+	}
+}
+
+func TestMonthGridFirstWeekday(t *testing.T) {
+	c, err := NewCalendar(WithMonth(2024, time.May), WithFirstWeekday(time.Monday))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := c.MonthGrid(2024, time.May)
+	if len(g.Weeks) != 5 {
+		t.Fatalf("%d weeks, want 5", len(g.Weeks))
+	}
+	if first := g.Weeks[0].Cells[0].Date; !first.Equal(date(2024, time.April, 29)) || first.Weekday() != time.Monday {
+		t.Errorf("first row starts on %s, want Monday 2024-04-29", first.Format("2006-01-02"))
+	}
+
+	// rows from Monday to Sunday span two GNSS weeks, and are labeled with
+	// the week of most of their days of the month
+	for i, wk := range g.Weeks {
+		if want := 2312 + i; wk.Week != want {
+			t.Errorf("week of row %d = %d, want %d", i, wk.Week, want)
+		}
+	}
+	sat, sun := g.Weeks[1].Cells[5], g.Weeks[1].Cells[6]
+	if sat.Week != 2313 || sat.Dow != 6 || sun.Week != 2314 || sun.Dow != 0 {
+		t.Errorf("Saturday and Sunday of row 1 are %d/%d and %d/%d, want 2313/6 and 2314/0", sat.Week, sat.Dow, sun.Week, sun.Dow)
+	}
+
+	c, err = NewCalendar(WithMonth(2024, time.May), WithFirstWeekday(time.Saturday))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g = c.MonthGrid(2024, time.May)
+	if first := g.Weeks[0].Cells[0].Date; !first.Equal(date(2024, time.April, 27)) {
+		t.Errorf("first row starts on %s, want Saturday 2024-04-27", first.Format("2006-01-02"))
+	}
+	if n := len(g.Weeks); n != 5 {
+		t.Errorf("%d weeks from Saturday, want 5", n)
+	}
+}
+
+func TestMonthGridBeforeEpoch(t *testing.T) {
+	// GST0 is Sunday, 1999-08-22, the fourth row of August 1999
+	c, err := NewCalendar(WithSatSys(gnss.SYSGAL), WithMonth(1999, time.August))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := c.MonthGrid(1999, time.August)
+	if len(g.Weeks) != 5 {
+		t.Fatalf("%d weeks, want 5", len(g.Weeks))
+	}
+	for i, wk := range g.Weeks {
+		want := -1
+		if i >= 3 {
+			want = i - 3
+		}
+		if wk.Week != want {
+			t.Errorf("week of row %d = %d, want %d", i, wk.Week, want)
+		}
+		for _, cell := range wk.Cells {
+			before := cell.Date.Before(gnss.GST0)
+			if before && (cell.Week != -1 || cell.Dow != -1) || !before && (cell.Week < 0 || cell.Dow < 0) {
+				t.Errorf("cell of %s has week %d dow %d", cell.Date.Format("2006-01-02"), cell.Week, cell.Dow)
+			}
+		}
+	}
+	if cell := g.Weeks[3].Cells[0]; cell.Week != 0 || cell.Dow != 0 || cell.Doy != 234 {
+		t.Errorf("cell of the epoch = %+v", cell)
+	}
+}
@@ -0,0 +1,158 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Locale is the names of months and weekdays in a language.
+type Locale struct {
+	Name        string     // e.g. "ja"
+	Months      [12]string // January to December
+	ShortMonths [12]string // abbreviations, up to 4 columns wide (a CJK character is 2 columns)
+	Weekdays    [7]string  // abbreviations of Sunday to Saturday, up to 3 columns wide
+
+	// MonthYear is the fmt layout of the headers of months, given the name
+	// of the month, the year and the number of the month by argument
+	// indexes, e.g. "%[2]d年%[3]d月" for "2024年5月". "%[1]s %4[2]d" (e.g.
+	// "May 2024") is used if empty.
+	MonthYear string
+
+	FirstWeekday time.Weekday // customary first day of the week
+}
+
+// English is the default locale.
+var English = &Locale{
+	Name:        "en",
+	Months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Weekdays:    [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+}
+
+// locales are the built-in locales by name.
+var locales = map[string]*Locale{
+	"en": English,
+	"de": {
+		Name:         "de",
+		FirstWeekday: time.Monday,
+		Months:       [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:  [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Weekdays:     [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	},
+	"fr": {
+		Name:         "fr",
+		FirstWeekday: time.Monday,
+		Months:       [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:  [12]string{"jan", "fév", "mar", "avr", "mai", "jun", "jul", "aoû", "sep", "oct", "nov", "déc"},
+		Weekdays:     [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+	},
+	"es": {
+		Name:         "es",
+		FirstWeekday: time.Monday,
+		Months:       [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:  [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		Weekdays:     [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"it": {
+		Name:         "it",
+		FirstWeekday: time.Monday,
+		Months:       [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		ShortMonths:  [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Weekdays:     [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	"pt": {
+		Name:         "pt",
+		FirstWeekday: time.Monday,
+		Months:       [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		ShortMonths:  [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		Weekdays:     [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	},
+	"nl": {
+		Name:         "nl",
+		FirstWeekday: time.Monday,
+		Months:       [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		ShortMonths:  [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		Weekdays:     [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+	"ja": {
+		Name:        "ja",
+		MonthYear:   "%[2]d年%[1]s",
+		Months:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		ShortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:    [7]string{"日", "月", "火", "水", "木", "金", "土"},
+	},
+	"zh": {
+		Name:        "zh",
+		MonthYear:   "%[2]d年%[3]d月",
+		Months:      [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		ShortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:    [7]string{"日", "一", "二", "三", "四", "五", "六"},
+	},
+	"ko": {
+		Name:        "ko",
+		MonthYear:   "%[2]d년 %[1]s",
+		Months:      [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		ShortMonths: [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		Weekdays:    [7]string{"일", "월", "화", "수", "목", "금", "토"},
+	},
+}
+
+// LookupLocale returns the built-in locale of name, a language code such
+// as "ja", or a locale name such as "de_DE.UTF-8" or "fr-CA", of which the
+// language is used. "C" and "POSIX" are English.
+func LookupLocale(name string) (*Locale, bool) {
+	if name == "C" || name == "POSIX" || strings.HasPrefix(name, "C.") {
+		return English, true
+	}
+	lang := strings.ToLower(name)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	l, ok := locales[lang]
+	return l, ok
+}
+
+// LocaleNames returns the names of the built-in locales, sorted.
+func LocaleNames() []string {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// locale returns the locale of c, English if not set.
+func (c Calendar) locale() *Locale {
+	if c.Locale == nil {
+		return English
+	}
+	return c.Locale
+}
+
+// monthName returns the name of month in the locale of c.
+func (c Calendar) monthName(month time.Month) string {
+	return c.locale().Months[month-1]
+}
+
+// monthYear returns the header of month of year in the locale of c, e.g.
+// "May 2024" or "2024年5月".
+func (c Calendar) monthYear(year int, month time.Month) string {
+	layout := c.locale().MonthYear
+	if layout == "" {
+		layout = "%[1]s %4[2]d"
+	}
+	return fmt.Sprintf(layout, c.monthName(month), year, int(month))
+}
+
+// shortMonth returns the abbreviation of month in the locale of c.
+func (c Calendar) shortMonth(month time.Month) string {
+	return c.locale().ShortMonths[month-1]
+}
+
+// weekdayName returns the abbreviation of wd in the locale of c.
+func (c Calendar) weekdayName(wd time.Weekday) string {
+	return c.locale().Weekdays[wd]
+}
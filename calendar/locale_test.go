@@ -0,0 +1,41 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMonthHeader(t *testing.T) {
+	tests := []struct {
+		locale string
+		year   int
+		month  time.Month
+		want   string
+	}{
+		{"en", 2024, time.May, "May 2024"},
+		{"de", 2024, time.March, "März 2024"},
+		{"ja", 2024, time.May, "2024年5月"},
+		{"zh", 2024, time.May, "2024年5月"},
+		{"zh", 2024, time.December, "2024年12月"},
+		{"ko", 2019, time.January, "2019년 1월"},
+	}
+	for _, tt := range tests {
+		l, ok := LookupLocale(tt.locale)
+		if !ok {
+			t.Fatalf("LookupLocale(%q) failed", tt.locale)
+		}
+		c, err := NewCalendar(WithLocale(l), WithMonth(tt.year, tt.month))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, layout := range []Layout{Layout1Month, LayoutWeekRows} {
+			c.Layout = layout
+			line, _, _ := strings.Cut(c.String(), "\n")
+			head := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "GPS"))
+			if head != tt.want {
+				t.Errorf("header of %s %d-%02d in layout %d = %q, want %q", tt.locale, tt.year, tt.month, layout, head, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// Errors of NewCalendar, which can be tested with errors.Is.
+var (
+	ErrInvalidMonth   = errors.New("invalid month")
+	ErrInvalidYear    = errors.New("invalid year")
+	ErrInvalidColumns = errors.New("invalid columns")
+	ErrInvalidWeekday = errors.New("invalid weekday")
+	ErrMoscowNotGLO   = errors.New("Moscow day boundaries are applied only to GLO")
+)
+
+// Option is an option of NewCalendar.
+type Option func(c *Calendar)
+
+// WithSatSys sets the satellite system of GNSS weeks [default: GPS].
+func WithSatSys(sys gnss.SatSys) Option {
+	return func(c *Calendar) { c.SatSys = sys }
+}
+
+// WithLayout sets the layout [default: Layout1Month].
+func WithLayout(layout Layout) Option {
+	return func(c *Calendar) { c.Layout = layout }
+}
+
+// WithReference sets the date of which the calendar is shown
+// [default: today].
+func WithReference(date time.Time) Option {
+	return func(c *Calendar) {
+		c.RefDate = date.UTC().Truncate(oneDay)
+		c.WholeYear = false
+		c.LastYear = 0
+	}
+}
+
+// WithYear shows the whole year in Layout1Year, which can be changed by
+// WithLayout after it; LayoutWeekRows and LayoutYearChart also show the
+// whole year.
+func WithYear(year int) Option {
+	return func(c *Calendar) {
+		c.RefDate = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		c.Layout = Layout1Year
+		c.WholeYear = true
+		c.LastYear = 0
+	}
+}
+
+// WithYears shows the whole years from first to last in Layout1Year, as
+// WithYear does for one year. The calendar is written a row of months at a
+// time by WriteTo, so that the range can be long, e.g. 1980-2100 for a
+// lookup table.
+func WithYears(first, last int) Option {
+	return func(c *Calendar) {
+		if last < first {
+			c.err = fmt.Errorf("%w: %d-%d", ErrInvalidYear, first, last)
+			return
+		}
+		WithYear(first)(c)
+		c.LastYear = last
+	}
+}
+
+// WithMonth shows the month of year. An invalid month is reported by
+// NewCalendar as ErrInvalidMonth.
+func WithMonth(year int, month time.Month) Option {
+	return func(c *Calendar) {
+		if month < time.January || time.December < month {
+			c.err = fmt.Errorf("%w: %d", ErrInvalidMonth, month)
+			return
+		}
+		c.RefDate = time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		c.WholeYear = false
+		c.LastYear = 0
+	}
+}
+
+// WithToday sets the day to be highlighted [default: today in UTC, or in
+// Moscow time with WithMoscow].
+func WithToday(date time.Time) Option {
+	return func(c *Calendar) { c.Today = date.UTC().Truncate(oneDay) }
+}
+
+// WithHighlight sets whether today is highlighted [default: true].
+func WithHighlight(highlight bool) Option {
+	return func(c *Calendar) { c.Highlight = highlight }
+}
+
+// WithCell sets the contents of day cells [default: CellDay].
+func WithCell(cell CellFormat) Option {
+	return func(c *Calendar) { c.Cell = cell }
+}
+
+// WithColumns sets the number of months per row of Layout1Year; 1, 2, 3,
+// 4, 6, or 12 [default: 3].
+func WithColumns(n int) Option {
+	return func(c *Calendar) { c.Columns = n }
+}
+
+// WithJulian shows doy instead of day of month.
+func WithJulian(julian bool) Option {
+	return func(c *Calendar) { c.Julian = julian }
+}
+
+// WithDow shows GNSS day of week under each day.
+func WithDow(dow bool) Option {
+	return func(c *Calendar) { c.Dow = dow }
+}
+
+// WithLeap underlines days on which a leap second is inserted.
+func WithLeap(leap bool) Option {
+	return func(c *Calendar) { c.Leap = leap }
+}
+
+// WithEvents marks the days of events in bold, e.g. GNSSEvents(), listed
+// by EventFootnote.
+func WithEvents(events []Event) Option {
+	return func(c *Calendar) { c.Events = events }
+}
+
+// WithEclipse shades the week numbers of the GNSS weeks touching eclipse
+// seasons of the orbital planes of the system, listed by EclipseFootnote.
+func WithEclipse(eclipse bool) Option {
+	return func(c *Calendar) { c.Eclipse = eclipse }
+}
+
+// WithWholeWeek highlights the other days of the GNSS week of today in
+// blue, with today highlighted by WithHighlight, e.g. for the work on
+// weekly products.
+func WithWholeWeek(whole bool) Option {
+	return func(c *Calendar) { c.WholeWeek = whole }
+}
+
+// WithColor sets whether escape sequences of colors and attributes are
+// written [default: true]. Without them, today is marked by brackets, e.g.
+// "[15]", or an asterisk, and the other marks are only in the footnotes.
+func WithColor(color bool) Option {
+	return func(c *Calendar) { c.NoColor = !color }
+}
+
+// WithBorder draws month blocks in boxes of box-drawing characters with
+// lines separating the header, the week rows, and the footer, e.g. for
+// screenshots and printing. The week-row layout is drawn in a box too.
+func WithBorder(border bool) Option {
+	return func(c *Calendar) { c.Border = border }
+}
+
+// WithFooter adds a line of the range of GNSS weeks and doy and the number
+// of days under each month, e.g. "W2303-2308 DOY 061-091 31 days". The
+// layouts other than of month blocks have no footers.
+func WithFooter(footer bool) Option {
+	return func(c *Calendar) { c.Footer = footer }
+}
+
+// WithSpaceWeather colors the days of high space weather activity, listed
+// by SpaceWeatherFootnote.
+func WithSpaceWeather(days []ActiveDay) Option {
+	return func(c *Calendar) { c.SpaceWeather = days }
+}
+
+// WithOffset shows the offset of the system time from UTC next to week
+// numbers.
+func WithOffset(offset bool) Option {
+	return func(c *Calendar) { c.Offset = offset }
+}
+
+// WithMoscow makes day boundaries follow Moscow time (UTC+3); only for
+// GLONASS.
+func WithMoscow(moscow bool) Option {
+	return func(c *Calendar) { c.Moscow = moscow }
+}
+
+// WithLocale sets the names of months and weekdays [default: English].
+// See LookupLocale for the built-in locales.
+func WithLocale(l *Locale) Option {
+	return func(c *Calendar) { c.Locale = l }
+}
+
+// WithFirstWeekday sets the weekday of the first column of month and
+// week-row layouts [default: time.Sunday], e.g. time.Monday, or
+// time.Saturday as in the Gulf region. GNSS weeks start on Sunday
+// regardless, so with other weekdays a row spans two GNSS weeks; day
+// cells show their own GNSS week and dow (WithCell, WithDow).
+func WithFirstWeekday(wd time.Weekday) Option {
+	return func(c *Calendar) { c.FirstWeekday = wd }
+}
+
+// WithEra shows Japanese era years next to years in headers, e.g.
+// "2024 (令和6)".
+func WithEra(era bool) Option {
+	return func(c *Calendar) { c.Era = era }
+}
+
+// NewCalendar returns the calendar configured by opts. By default, it is
+// the GPS calendar of the current month with today highlighted.
+func NewCalendar(opts ...Option) (*Calendar, error) {
+	c := &Calendar{
+		SatSys:    gnss.SYSGPS,
+		Highlight: true,
+		Layout:    Layout1Month,
+		Columns:   3,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	if !c.RefDate.IsZero() && (c.RefDate.Year() < 1980 || 9999 < c.RefDate.Year()) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidYear, c.RefDate.Year())
+	}
+	if 9999 < c.LastYear {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidYear, c.LastYear)
+	}
+
+	info, ok := gnss.Lookup(string(c.SatSys))
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", gnss.ErrUnknownSatSys, c.SatSys)
+	}
+	if c.Moscow && c.SatSys != gnss.SYSGLO {
+		return nil, fmt.Errorf("%w, not %s", ErrMoscowNotGLO, c.SatSys)
+	}
+	if c.FirstWeekday < time.Sunday || time.Saturday < c.FirstWeekday {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidWeekday, c.FirstWeekday)
+	}
+	if c.Columns < 1 || 12%c.Columns != 0 {
+		return nil, fmt.Errorf("%w: %d. valid columns: 1, 2, 3, 4, 6, 12", ErrInvalidColumns, c.Columns)
+	}
+
+	if c.Today.IsZero() {
+		c.Today = time.Now().UTC().Truncate(oneDay)
+		if c.Moscow {
+			// a GLONASS day starts at 21:00 UTC of the day before
+			c.Today = gnss.MoscowDate(time.Now())
+		}
+	}
+	if c.RefDate.IsZero() {
+		c.RefDate = c.Today
+	}
+	c.SysTime0 = info.EpochAt(c.RefDate)
+
+	return c, nil
+}
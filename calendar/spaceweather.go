@@ -0,0 +1,39 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+)
+
+// ActiveDay is a day of high space weather activity, e.g. of Kp above a
+// threshold, colored in calendars with WithSpaceWeather.
+type ActiveDay struct {
+	Date  time.Time // 00:00 UTC
+	Label string    // indices of the day, e.g. "Kp 6.3, Ap 48, F10.7 182"
+}
+
+// activeDay returns the active day of c on date, if any.
+func (c Calendar) activeDay(date time.Time) (ActiveDay, bool) {
+	for _, d := range c.SpaceWeather {
+		if d.Date.Equal(date) {
+			return d, true
+		}
+	}
+	return ActiveDay{}, false
+}
+
+// SpaceWeatherFootnote returns the footnote listing the active days of c
+// in the period shown.
+func (c Calendar) SpaceWeatherFootnote() (msg []string) {
+	first, last := c.Period()
+	for _, d := range c.SpaceWeather {
+		if d.Date.Before(first) || !d.Date.Before(last) {
+			continue
+		}
+		msg = append(msg, fmt.Sprintf("%s (doy %03d): %s", d.Date.Format("2006-01-02"), doy(d.Date), d.Label))
+	}
+	if len(msg) == 0 {
+		msg = append(msg, "no day above the space weather thresholds in this period")
+	}
+	return msg
+}
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// displayWidth returns the number of terminal columns taken by s. The
+// escape sequences of highlights take none, and the wide characters of
+// East Asian scripts, e.g. 月 or 월, take two.
+func displayWidth(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\033' {
+			// skip to the end of "\033[...m"
+			j := strings.IndexByte(s[i:], 'm')
+			if j < 0 {
+				break
+			}
+			i += j + 1
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining marks are drawn over the previous character
+		case isWide(r):
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// isWide reports whether r takes two columns.
+func isWide(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return true
+	}
+	return false
+}
+
+// alignLeft returns s padded with spaces on the right to w columns.
+func alignLeft(s string, w int) string {
+	if n := w - displayWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// alignRight returns s padded with spaces on the left to w columns.
+func alignRight(s string, w int) string {
+	if n := w - displayWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
+
+// center returns s after prefix, centered in cols columns as the headers
+// of calendars are, without the trailing spaces.
+func center(prefix, s string, cols int) string {
+	return prefix + alignRight(s, cols/2+displayWidth(s)/2)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/cache"
+	"github.com/satoshi-pes/gnsscal/calendar"
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// leapSecondsURL is the leap-seconds.list maintained by IERS and
+// distributed with the IANA time zone database.
+const leapSecondsURL = "https://data.iana.org/time-zones/tzdb/leap-seconds.list"
+
+// leapSecondsMaxAge is the age after which the cached list is refreshed.
+var leapSecondsMaxAge = 30 * oneDay
+
+// leapSecondsSource is leap-seconds.list in the cache, expiring at the
+// expiration date of the list.
+var leapSecondsSource = cache.Source{
+	Name:   "leap-seconds.list",
+	URL:    leapSecondsURL,
+	MaxAge: leapSecondsMaxAge,
+	Check: func(data []byte) (time.Time, error) {
+		_, expire, err := gnss.ParseLeapSecondsList(bytes.NewReader(data))
+		return expire, err
+	},
+}
+
+// loadLeapSeconds returns the leap seconds of the cached leap-seconds.list.
+// The cache is refreshed if it does not exist, is older than
+// leapSecondsMaxAge, has expired, or refresh is true. If the refresh fails,
+// the cached list is used with a warning, or the built-in table if no cache
+// exists. The refresh is abandoned when ctx is done.
+func loadLeapSeconds(ctx context.Context, refresh bool) (ls []gnss.LeapSecond, expire time.Time, warn error) {
+	data, _, err := cache.Get(ctx, leapSecondsSource, refresh)
+	if data == nil {
+		return gnss.LeapSeconds(), gnss.LeapSecondsExpire(), fmt.Errorf("failed to refresh leap seconds: %v; using the built-in table", err)
+	}
+	if err != nil {
+		warn = fmt.Errorf("failed to refresh leap seconds: %v; using the cache", err)
+	}
+	ls, expire, perr := gnss.ParseLeapSecondsList(bytes.NewReader(data))
+	if perr != nil {
+		return gnss.LeapSeconds(), gnss.LeapSecondsExpire(), perr
+	}
+	return ls, expire, warn
+}
+
+// checkLeapFootnote returns the footnote telling whether a leap second is
+// scheduled in the period shown in c, according to the IERS data.
+func checkLeapFootnote(c *calendar.Calendar, ls []gnss.LeapSecond, expire time.Time) (msg []string) {
+	first, last := c.Period()
+	for _, l := range ls {
+		if l.Date.Before(first) || !l.Date.Before(last) {
+			continue
+		}
+		if l.Date.Before(c.Today) {
+			msg = append(msg, fmt.Sprintf("leap second inserted at %s 23:59:60 UTC", l.Date.Format("2006-01-02")))
+		} else {
+			msg = append(msg, fmt.Sprintf("WARNING: leap second scheduled at %s 23:59:60 UTC", l.Date.Format("2006-01-02")))
+		}
+	}
+	if len(msg) == 0 {
+		msg = append(msg, "no leap second scheduled in this period")
+	}
+	if last.After(expire) {
+		msg = append(msg, fmt.Sprintf("leap seconds after %s are not announced yet", expire.Format("2006-01-02")))
+	}
+	return msg
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runClock shows UTC, the system time, the GNSS week and sow, and the doy
+// ticking in real time on a line, or on a panel with -panel, until
+// interrupted.
+func runClock(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("clock", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS time to be shown")
+	panel := fs.Bool("panel", false, "shows a panel of lines instead of a line")
+	interval := fs.Duration("interval", time.Second, "interval of updates; 100ms or more")
+	count := fs.Int("n", 0, "exits after n updates; 0 to run until interrupted")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal clock [-satsys sys] [-panel] [-interval d] [-n count]\n")
+	}
+	fs.Parse(args)
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+	if *interval < 100*time.Millisecond {
+		return fmt.Errorf("invalid interval: %s, interval must be 100ms or more", *interval)
+	}
+	enableVT(os.Stdout) // lines are redrawn by escape sequences
+
+	// updates are aligned to the multiples of the interval
+	lines := 0
+	for n := 0; *count == 0 || n < *count; n++ {
+		if n > 0 {
+			now := time.Now()
+			select {
+			case <-ctx.Done():
+				fmt.Println()
+				return nil
+			case <-time.After(now.Truncate(*interval).Add(*interval).Sub(now)):
+			}
+		}
+
+		t := time.Now().UTC().Truncate(*interval)
+		text, err := clockText(sys, t, *interval, *panel)
+		if err != nil {
+			return err
+		}
+		lines = redraw(os.Stdout, text, lines)
+	}
+	fmt.Println()
+	return nil
+}
+
+// clockText returns the line, or the lines of the panel, of t.
+func clockText(sys gnss.SystemInfo, t time.Time, interval time.Duration, panel bool) (string, error) {
+	g, err := gnss.TimeOf(sys.Name, t)
+	if err != nil {
+		return "", err
+	}
+	layout := "2006-01-02 15:04:05"
+	if interval%time.Second != 0 {
+		layout += ".0"
+	}
+	utc := t.Format(layout)
+	st := t.Add(sys.UTCOffset(t)).Format(layout)
+	sow := trimFloat(g.Seconds(), 1)
+
+	if !panel {
+		return fmt.Sprintf("UTC %s  %s %s  week %d dow %d sow %s  DOY %03d",
+			utc, sys.Name, st, g.Week, g.Dow(), sow, t.YearDay()), nil
+	}
+	return fmt.Sprintf("%-10s%s\n%-10s%s\n%-10s%d\n%-10s%d\n%-10s%s\n%-10s%03d",
+		"UTC", utc, sys.Name, st, "Week", g.Week, "Dow", g.Dow(), "SOW", sow, "DOY", t.YearDay()), nil
+}
+
+// redraw writes text over the lines previously written, and returns the
+// number of lines written.
+func redraw(w io.Writer, text string, prev int) int {
+	var b strings.Builder
+	if prev > 1 {
+		fmt.Fprintf(&b, "\033[%dA", prev-1) // cursor up to the first line
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("\r\033[K" + line) // clear the line
+	}
+	io.WriteString(w, b.String())
+	return strings.Count(text, "\n") + 1
+}
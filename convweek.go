@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runConvWeek prints the week numbers of other systems for weeks.
+func runConvWeek(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("convweek", flag.ExitOnError)
+	from := fs.String("from", "GPS", "satellite system of the given weeks")
+	to := fs.String("to", "", "satellite system to convert to; all systems if not given")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal convweek [-from sys] [-to sys] week...\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("week is not given")
+	}
+
+	fromSys, err := lookupSatSys(*from)
+	if err != nil {
+		return err
+	}
+
+	var targets []gnss.SystemInfo
+	if *to != "" {
+		toSys, err := lookupSatSys(*to)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, toSys)
+	} else {
+		for _, s := range gnss.Systems() {
+			if s.EpochFn == nil && s.Name != fromSys.Name {
+				targets = append(targets, s)
+			}
+		}
+	}
+
+	for _, arg := range fs.Args() {
+		week, err := strconv.Atoi(arg)
+		if err != nil || week < 0 {
+			return fmt.Errorf("invalid week: %s", arg)
+		}
+
+		if *to != "" {
+			w, err := gnss.ConvertWeek(week, fromSys.Name, targets[0].Name)
+			if err != nil {
+				return err
+			}
+			fmt.Println(w)
+			continue
+		}
+
+		fmt.Printf("%s week %d (%s)\n", fromSys.Name, week, fromSys.Epoch.AddDate(0, 0, 7*week).Format("2006-01-02"))
+		for _, s := range targets {
+			if w, err := gnss.ConvertWeek(week, fromSys.Name, s.Name); err == nil {
+				fmt.Printf("  %-4s%6d\n", s.Name, w)
+			} else {
+				fmt.Printf("  %-4s%6s\n", s.Name, "-")
+			}
+		}
+	}
+
+	return nil
+}
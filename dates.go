@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateStyle is the notation of dates in listings (table, weeks) and
+// query results, chosen by -dates.
+type dateStyle int
+
+const (
+	dateCalendar dateStyle = iota // calendar date, e.g. 2024-05-02
+	dateOrdinal                   // ISO 8601 ordinal date, e.g. 2024-123
+	dateWeek                      // ISO 8601 week date, e.g. 2024-W18-4
+)
+
+// dateStyles maps the values of -dates to date styles.
+var dateStyles = map[string]dateStyle{
+	"calendar": dateCalendar,
+	"ordinal":  dateOrdinal,
+	"week":     dateWeek,
+}
+
+// parseDateStyle returns the date style of name.
+func parseDateStyle(name string) (dateStyle, error) {
+	ds, ok := dateStyles[name]
+	if !ok {
+		return 0, fmt.Errorf("invalid date style: '%s'. valid styles: calendar, ordinal, week", name)
+	}
+	return ds, nil
+}
+
+// date returns the date of t in the style. The year of a week date is the
+// ISO year, which differs from the calendar year in the first and the last
+// few days of some years, and its weekdays are from 1 (Monday) to 7
+// (Sunday), unlike GNSS dow.
+func (ds dateStyle) date(t time.Time) string {
+	switch ds {
+	case dateOrdinal:
+		return t.Format("2006-002")
+	case dateWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d-%d", year, week, (t.Weekday()+6)%7+1)
+	}
+	return t.Format("2006-01-02")
+}
+
+// dateTime returns t in the style with the time of day, e.g.
+// "2024-123 12:00:00.5".
+func (ds dateStyle) dateTime(t time.Time) string {
+	return ds.date(t) + t.Format(" 15:04:05.999999999")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runDiff prints the difference between two epochs.
+func runDiff(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of epochs given by GNSS week")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal diff [-satsys sys] epoch1 epoch2\n\n")
+		fmt.Fprintf(fs.Output(), "Epochs are given in the forms of the query command, e.g. 2024:123 or 2300:3:0\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("two epochs are required")
+	}
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+
+	var epochs [2]time.Time
+	for i, arg := range fs.Args() {
+		if epochs[i], err = gnss.ParseTime(sys.Name, arg); err != nil {
+			return err
+		}
+	}
+	t1, t2 := epochs[0], epochs[1]
+
+	utc := t2.Sub(t1)
+	gpst := gnss.Elapsed(t1, t2)
+	days := utc.Hours() / 24
+
+	leaps := int((gpst - utc) / time.Second)
+	if leaps < 0 {
+		leaps = -leaps
+	}
+
+	fmt.Printf("%-14s%s\n", "From", formatSeconds(t1))
+	fmt.Printf("%-14s%s\n", "To", formatSeconds(t2))
+	fmt.Printf("%-14s%s\n", "Days", formatFloat(days))
+	fmt.Printf("%-14s%s\n", "Weeks", formatWeeks(utc))
+	fmt.Printf("%-14s%s s\n", "UTC", formatFloat(utc.Seconds()))
+	fmt.Printf("%-14s%s s (%d leap seconds in between)\n", "GPST", formatFloat(gpst.Seconds()), leaps)
+
+	return nil
+}
+
+// formatWeeks formats d in weeks and days, with the sign on the whole value,
+// e.g. "2 weeks 3.5 days" or "-(0 weeks 1 days)".
+func formatWeeks(d time.Duration) string {
+	if d < 0 {
+		return "-(" + formatWeeks(-d) + ")"
+	}
+	return fmt.Sprintf("%d weeks %s days", int(d/oneWeek), formatFloat(float64(d%oneWeek)/float64(oneDay)))
+}
+
+// formatFloat formats v without trailing zeros of the fraction.
+func formatFloat(v float64) string {
+	s := fmt.Sprintf("%.9f", v)
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
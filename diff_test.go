@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatWeeks(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0 weeks 0 days"},
+		{oneDay, "0 weeks 1 days"},
+		{-oneDay, "-(0 weeks 1 days)"},
+		{2*oneWeek + 3*oneDay + 12*time.Hour, "2 weeks 3.5 days"},
+		{-(2*oneWeek + 3*oneDay + 12*time.Hour), "-(2 weeks 3.5 days)"},
+		{-oneWeek, "-(1 weeks 0 days)"},
+	}
+	for _, tt := range tests {
+		if got := formatWeeks(tt.d); got != tt.want {
+			t.Errorf("formatWeeks(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// offsets of the system times from TAI, to tell how the offset from UTC
+// is derived from the leap seconds
+const (
+	taiMinusGPST = 19 * time.Second
+	taiMinusBDT  = 33 * time.Second
+)
+
+// printExplanation prints the steps converting the UTC time t to the GNSS
+// week of sys: the leap seconds applied, the offset of the system time,
+// the epoch of week counting, the time since the epoch, and its division
+// into weeks, e.g. for checking disagreements with other tools.
+func printExplanation(w io.Writer, sys gnss.SystemInfo, t time.Time) error {
+	g, err := gnss.TimeOf(sys.Name, t)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Explanation:\n")
+	fmt.Fprintf(w, "  %-21s%s\n", "UTC", formatSeconds(t))
+
+	// leap seconds inserted before t
+	tai := gnss.TAIMinusUTC(t)
+	var n int
+	var last gnss.LeapSecond
+	for _, l := range gnss.LeapSeconds() {
+		if !t.Before(l.Date.Add(oneDay)) {
+			n, last = n+1, l
+		}
+	}
+	if n > 0 {
+		fmt.Fprintf(w, "  %-21s%d s; 10 s of 1972 and %d leap seconds, the last at %s 23:59:60\n",
+			"TAI-UTC", int(tai.Seconds()), n, last.Date.Format("2006-01-02"))
+	} else {
+		fmt.Fprintf(w, "  %-21s%d s; no leap second is inserted yet\n", "TAI-UTC", int(tai.Seconds()))
+	}
+
+	// offset of the system time from UTC
+	off := sys.UTCOffset(t)
+	name := string(sys.Name) + "-UTC"
+	secs := func(d time.Duration) string { return trimFloat(d.Seconds(), 9) + " s" }
+	switch tai - off {
+	case taiMinusGPST:
+		fmt.Fprintf(w, "  %-21s%s = TAI-UTC - %s (TAI-GPST)\n", name, secs(off), secs(taiMinusGPST))
+	case taiMinusBDT:
+		fmt.Fprintf(w, "  %-21s%s = TAI-UTC - %s (TAI-BDT)\n", name, secs(off), secs(taiMinusBDT))
+	default:
+		fmt.Fprintf(w, "  %-21s%s; a fixed offset from UTC\n", name, secs(off))
+	}
+	st := t.Add(off)
+	fmt.Fprintf(w, "  %-21s%s = UTC + %s\n", string(sys.Name)+" time", formatSeconds(st), secs(off))
+
+	// epoch of week counting and the division into weeks
+	epoch := sys.EpochAt(st)
+	if sys.EpochFn != nil {
+		fmt.Fprintf(w, "  %-21s%s; week counting restarts periodically\n", "Epoch of weeks", epoch.Format("2006-01-02"))
+	} else {
+		fmt.Fprintf(w, "  %-21s%s\n", "Epoch of weeks", epoch.Format("2006-01-02"))
+	}
+	d := st.Sub(epoch)
+	days, sod := int(d/oneDay), d%oneDay
+	fmt.Fprintf(w, "  %-21s%d days + %s\n", "Since epoch", days, secs(sod))
+	fmt.Fprintf(w, "  %-21s%d days / 7 = week %d, day %d\n", "Week", days, g.Week, g.Dow())
+	fmt.Fprintf(w, "  %-21s%d * 86400 s + %s = %s\n", "Seconds of week", g.Dow(), secs(sod), secs(g.Sow))
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// atomFeed is an Atom (RFC 4287) feed.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary"`
+	Content atomText `xml:"content"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// rssFeed is an RSS 2.0 feed.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+// rssGUID is the guid of an item; the URNs of events are not URLs.
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	ID          string `xml:",chardata"`
+}
+
+// feedEvents returns the upcoming events at now and the campaign events
+// from the day of now, sorted by date.
+func feedEvents(now time.Time, campaigns []upcomingEvent) []upcomingEvent {
+	events := upcomingEvents(now)
+	today := now.UTC().Truncate(oneDay)
+	for _, e := range campaigns {
+		if !e.Date.Before(today) {
+			events = append(events, e)
+		}
+	}
+	sortEvents(events)
+	return events
+}
+
+// eventID returns the identifier of e, which is stable across requests.
+func eventID(e upcomingEvent) string {
+	return fmt.Sprintf("urn:gnsscal:%s:%s", e.Date.Format("2006-01-02"),
+		strings.ToLower(strings.Join(strings.Fields(e.Name), "-")))
+}
+
+// eventSummary describes the date of e in GNSS week and doy.
+func eventSummary(e upcomingEvent) string {
+	g, _ := gnss.TimeOf(gnss.SYSGPS, e.Date)
+	if strings.HasSuffix(e.Name, " week rollover") {
+		return fmt.Sprintf("%s on %s (DOY %03d) at week %d; the broadcast week number wraps to 0",
+			e.Name, e.Date.Format("2006-01-02"), doy(e.Date), e.Week)
+	}
+	return fmt.Sprintf("%s on %s (GPS week %d, day %d, DOY %03d)",
+		e.Name, e.Date.Format("2006-01-02"), g.Week, g.Dow(), doy(e.Date))
+}
+
+// eventLink returns the URL of the calendar page of the month of e on the
+// server of base, e.g. "https://example.com".
+func eventLink(base string, e upcomingEvent) string {
+	return fmt.Sprintf("%s/%04d/%02d?events=1", base, e.Date.Year(), int(e.Date.Month()))
+}
+
+// writeAtom writes the Atom feed of events; link is the URL of the feed,
+// and the entries link to the calendar pages on the server of base.
+func writeAtom(w io.Writer, events []upcomingEvent, base, link string, now time.Time) error {
+	feed := atomFeed{
+		Title:   "gnsscal upcoming events",
+		ID:      "urn:gnsscal:upcoming",
+		Updated: now.UTC().Truncate(oneDay).Format(time.RFC3339),
+		Link:    atomLink{Href: link, Rel: "self"},
+		Author:  atomAuthor{Name: "gnsscal"},
+	}
+	for _, e := range events {
+		summary := eventSummary(e)
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   e.Name,
+			ID:      eventID(e),
+			Updated: e.Date.Format(time.RFC3339),
+			Link:    atomLink{Href: eventLink(base, e), Rel: "alternate"},
+			Summary: summary,
+			Content: atomText{Type: "text", Text: summary},
+		})
+	}
+	return writeXML(w, feed)
+}
+
+// writeRSS writes the RSS feed of events; link is the URL of the feed,
+// and the items link to the calendar pages on the server of base.
+func writeRSS(w io.Writer, events []upcomingEvent, base, link string) error {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "gnsscal upcoming events",
+			Link:        link,
+			Description: "GNSS week rollovers, leap seconds, and campaigns",
+		},
+	}
+	for _, e := range events {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       e.Name,
+			Link:        eventLink(base, e),
+			GUID:        rssGUID{ID: eventID(e)},
+			PubDate:     e.Date.Format(time.RFC1123Z),
+			Description: eventSummary(e),
+		})
+	}
+	return writeXML(w, feed)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// feedHandler serves the feed of the upcoming events and campaigns, in Atom
+// if atom is true, or in RSS.
+func feedHandler(campaigns []upcomingEvent, atom bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		events := feedEvents(now, campaigns)
+		base := requestScheme(r) + "://" + r.Host
+		link := base + r.URL.Path
+		if atom {
+			w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+			writeAtom(w, events, base, link, now)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		writeRSS(w, events, base, link)
+	}
+}
+
+// requestScheme returns the scheme of the URL requested by r; of the first
+// proxy in X-Forwarded-Proto if given, or "https" if r is over TLS.
+func requestScheme(r *http.Request) string {
+	if v := r.Header.Get("X-Forwarded-Proto"); v != "" {
+		proto, _, _ := strings.Cut(v, ",")
+		switch proto = strings.ToLower(strings.TrimSpace(proto)); proto {
+		case "http", "https":
+			return proto
+		}
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
@@ -0,0 +1,103 @@
+package gnss
+
+import (
+	"testing"
+	"time"
+)
+
+// epochForms are the forms of ParseTime, which are parsed without
+// allocation.
+var epochForms = []string{
+	"2024:123",
+	"2024:123:43200",
+	"2024:123:43200.5",
+	"2024:123:12:00:00.5",
+	"2300:3:259200",
+	"2300:3",
+	"2300:259200",
+	"2024-05-02",
+	"2024-05-02 12:00:00.5",
+	"2024-05-02T12:00:00Z",
+	"@1714651200.5",
+	"MJD60432.5",
+	"> 2024 05 03 12 00 30.0000000  0 12",
+	" 24  5  3 12  0 30.0000000  0  8G01G02",
+	"*  2024  5  3 12  0 30.00000000",
+}
+
+func TestParseTimeAllocs(t *testing.T) {
+	for _, s := range epochForms {
+		if n := testing.AllocsPerRun(100, func() { ParseTime(SYSGPS, s) }); n != 0 {
+			t.Errorf("ParseTime(%q) allocates %v times", s, n)
+		}
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	for _, tt := range []struct{ layout, s string }{
+		{"%Y%j", "2024123"},
+		{"%Y-%m-%d %H:%i:%s", "2024-05-02 12:00:00"},
+		{"igs%W%N.sp3", "igs23004.sp3"},
+		{"%W %S", "2300 259200"},
+		{"%M", "60432"},
+		{"%U", "1714651200"},
+	} {
+		if n := testing.AllocsPerRun(100, func() { Parse(tt.layout, SYSGPS, tt.s) }); n != 0 {
+			t.Errorf("Parse(%q, %q) allocates %v times", tt.layout, tt.s, n)
+		}
+	}
+}
+
+func TestTimeOfAllocs(t *testing.T) {
+	utc := date(2024, 5, 2).Add(12 * time.Hour)
+	for _, sys := range []SatSys{SYSGPS, SYSGAL, SYSBDS, SYSGLO} {
+		if n := testing.AllocsPerRun(100, func() { TimeOf(sys, utc) }); n != 0 {
+			t.Errorf("TimeOf(%s) allocates %v times", sys, n)
+		}
+	}
+}
+
+func TestAppendFormatAllocs(t *testing.T) {
+	utc := date(2024, 5, 2).Add(12 * time.Hour)
+	buf := make([]byte, 0, 64)
+	for _, layout := range []string{"igs%W%N.sp3", "%Y %j %X", "%Y-%m-%d %H:%i:%s.%f", "%W %S %M %U"} {
+		if n := testing.AllocsPerRun(100, func() { AppendFormat(buf[:0], layout, SYSGPS, utc) }); n != 0 {
+			t.Errorf("AppendFormat(%q) allocates %v times", layout, n)
+		}
+	}
+}
+
+func BenchmarkParseTime(b *testing.B) {
+	for _, s := range []string{"2024:123:43200", "2300:3:259200", "2024-05-02 12:00:00", "> 2024 05 03 12 00 30.0000000"} {
+		b.Run(s, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				ParseTime(SYSGPS, s)
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		Parse("%Y-%m-%d %H:%i:%s", SYSGPS, "2024-05-02 12:00:00")
+	}
+}
+
+func BenchmarkTimeOf(b *testing.B) {
+	utc := date(2024, 5, 2).Add(12 * time.Hour)
+	b.ReportAllocs()
+	for b.Loop() {
+		TimeOf(SYSGPS, utc)
+	}
+}
+
+func BenchmarkAppendFormat(b *testing.B) {
+	utc := date(2024, 5, 2).Add(12 * time.Hour)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf, _ = AppendFormat(buf[:0], "igs%W%N.sp3 %Y %j %X", SYSGPS, utc)
+	}
+}
@@ -0,0 +1,179 @@
+package gnss
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Archive is a remote archive of GNSS data and products.
+//
+// Paths maps a data type to the directory layout of the archive. The layout
+// may contain the following placeholders, which are replaced by Path:
+//
+//	{yyyy}  4-digit year
+//	{yy}    2-digit year
+//	{ddd}   3-digit day of year
+//	{hh}    2-digit hour
+//	{wwww}  4-digit GPS week
+//	{d}     GPS day of week
+type Archive struct {
+	Name    string            // e.g. "CDDIS"
+	BaseURL string            // e.g. "https://cddis.nasa.gov/archive"
+	Paths   map[string]string // e.g. "daily": "/gnss/data/daily/{yyyy}/{ddd}/{yy}d/"
+}
+
+// data types of archives
+const (
+	ArchiveDaily    = "daily"    // daily observation files
+	ArchiveNav      = "nav"      // daily navigation files
+	ArchiveMet      = "met"      // daily meteorological files
+	ArchiveHourly   = "hourly"   // hourly observation and navigation files
+	ArchiveHighrate = "highrate" // high-rate observation files
+	ArchiveProducts = "products" // products in GPS week directories
+)
+
+// registry of archives
+var (
+	archivesMu sync.RWMutex
+	archives   []Archive
+)
+
+func init() {
+	for _, a := range []Archive{
+		{
+			Name:    "CDDIS",
+			BaseURL: "https://cddis.nasa.gov/archive",
+			Paths: map[string]string{
+				ArchiveDaily:    "/gnss/data/daily/{yyyy}/{ddd}/{yy}d/",
+				ArchiveNav:      "/gnss/data/daily/{yyyy}/{ddd}/{yy}p/",
+				ArchiveMet:      "/gnss/data/daily/{yyyy}/{ddd}/{yy}m/",
+				ArchiveHourly:   "/gnss/data/hourly/{yyyy}/{ddd}/{hh}/",
+				ArchiveHighrate: "/gnss/data/highrate/{yyyy}/{ddd}/{yy}d/{hh}/",
+				ArchiveProducts: "/gnss/products/{wwww}/",
+			},
+		},
+		{
+			Name:    "IGN",
+			BaseURL: "https://igs.ign.fr/pub/igs",
+			Paths: map[string]string{
+				ArchiveDaily:    "/data/{yyyy}/{ddd}/",
+				ArchiveNav:      "/data/{yyyy}/{ddd}/",
+				ArchiveMet:      "/data/{yyyy}/{ddd}/",
+				ArchiveHourly:   "/data/hourly/{yyyy}/{ddd}/",
+				ArchiveHighrate: "/data/highrate/{yyyy}/{ddd}/",
+				ArchiveProducts: "/products/{wwww}/",
+			},
+		},
+		{
+			Name:    "BKG",
+			BaseURL: "https://igs.bkg.bund.de/root_ftp/IGS",
+			Paths: map[string]string{
+				ArchiveDaily:    "/obs/{yyyy}/{ddd}/",
+				ArchiveNav:      "/BRDC/{yyyy}/{ddd}/",
+				ArchiveMet:      "/obs/{yyyy}/{ddd}/",
+				ArchiveHourly:   "/nrt/{ddd}/{hh}/",
+				ArchiveHighrate: "/highrate/{yyyy}/{ddd}/{hh}/",
+				ArchiveProducts: "/products/{wwww}/",
+			},
+		},
+	} {
+		if err := RegisterArchive(a); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterArchive adds an archive to the registry.
+// It returns an error if the name is already registered.
+func RegisterArchive(a Archive) error {
+	if a.Name == "" {
+		return fmt.Errorf("empty archive name")
+	}
+
+	archivesMu.Lock()
+	defer archivesMu.Unlock()
+
+	if _, ok := lookupArchive(a.Name); ok {
+		return fmt.Errorf("archive already registered: '%s'", a.Name)
+	}
+	archives = append(archives, a)
+
+	return nil
+}
+
+// LookupArchive returns the archive registered with the name.
+// The name is case-insensitive.
+func LookupArchive(name string) (Archive, bool) {
+	archivesMu.RLock()
+	defer archivesMu.RUnlock()
+
+	return lookupArchive(name)
+}
+
+func lookupArchive(name string) (Archive, bool) {
+	for _, a := range archives {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+	return Archive{}, false
+}
+
+// Archives returns the registered archives in order of registration.
+func Archives() []Archive {
+	archivesMu.RLock()
+	defer archivesMu.RUnlock()
+
+	return append([]Archive(nil), archives...)
+}
+
+// DataTypes returns the data types of the archive in sorted order.
+func (a Archive) DataTypes() []string {
+	var types []string
+	for t := range a.Paths {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Path returns the remote directory path of the data type at t, which is
+// in GPS time as the archives are organized. t may be before the epoch of
+// GPS unless the layout contains {wwww} or {d}.
+func (a Archive) Path(dataType string, t time.Time) (string, error) {
+	layout, ok := a.Paths[dataType]
+	if !ok {
+		return "", fmt.Errorf("unknown data type of %s: '%s'. valid types: %s", a.Name, dataType, strings.Join(a.DataTypes(), ", "))
+	}
+
+	t = t.UTC()
+	oldnew := []string{
+		"{yyyy}", fmt.Sprintf("%04d", t.Year()),
+		"{yy}", fmt.Sprintf("%02d", t.Year()%100),
+		"{ddd}", fmt.Sprintf("%03d", t.YearDay()),
+		"{hh}", fmt.Sprintf("%02d", t.Hour()),
+	}
+	if strings.Contains(layout, "{wwww}") || strings.Contains(layout, "{d}") {
+		g, err := FromSystemTime(SYSGPS, t)
+		if err != nil {
+			return "", err
+		}
+		oldnew = append(oldnew,
+			"{wwww}", fmt.Sprintf("%04d", g.Week),
+			"{d}", fmt.Sprintf("%d", g.Dow()),
+		)
+	}
+	return strings.NewReplacer(oldnew...).Replace(layout), nil
+}
+
+// URL returns the remote directory URL of the data type at t.
+func (a Archive) URL(dataType string, t time.Time) (string, error) {
+	path, err := a.Path(dataType, t)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(a.BaseURL, "/") + path, nil
+}
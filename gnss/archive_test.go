@@ -0,0 +1,34 @@
+package gnss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArchivePath(t *testing.T) {
+	a, ok := LookupArchive("CDDIS")
+	if !ok {
+		t.Fatal("CDDIS is not registered")
+	}
+	tests := []struct {
+		dataType string
+		t        time.Time
+		want     string
+	}{
+		{ArchiveDaily, date(2024, 3, 14), "/gnss/data/daily/2024/074/24d/"},
+		{ArchiveHourly, date(2024, 3, 14).Add(7 * time.Hour), "/gnss/data/hourly/2024/074/07/"},
+		{ArchiveDaily, date(1979, 12, 31), "/gnss/data/daily/1979/365/79d/"},
+		{ArchiveProducts, date(1980, 1, 6), "/gnss/products/0000/"},
+		// the last seconds of a GPS week are not of the next week by leap seconds
+		{ArchiveProducts, date(2019, 4, 6).Add(24*time.Hour - time.Second), "/gnss/products/2047/"},
+	}
+	for _, tt := range tests {
+		got, err := a.Path(tt.dataType, tt.t)
+		if err != nil || got != tt.want {
+			t.Errorf("Path(%s, %s) = %q, %v, want %q", tt.dataType, tt.t, got, err, tt.want)
+		}
+	}
+	if _, err := a.Path(ArchiveProducts, date(1980, 1, 5)); err == nil {
+		t.Errorf("Path(%s, 1980-01-05): no error", ArchiveProducts)
+	}
+}
@@ -0,0 +1,29 @@
+package gnss
+
+import "time"
+
+// BDT is aligned with UTC at 2006-01-01 00:00:00 UTC and, like GPS time,
+// has no leap seconds since then. So BDT is behind GPS time by the constant
+// 14 s, the leap seconds inserted between 1980 and 2006.
+const gpstMinusBDT = 14 * time.Second
+
+// UTCToBDT returns the calendar time in BDT of t (UTC).
+func UTCToBDT(t time.Time) time.Time {
+	return UTCToGPST(t).Add(-gpstMinusBDT)
+}
+
+// BDTToUTC returns the UTC time of bdt, which is a calendar time in BDT.
+// The location of bdt is ignored.
+func BDTToUTC(bdt time.Time) time.Time {
+	return GPSTToUTC(bdt.Add(gpstMinusBDT))
+}
+
+// GPSTToBDT returns the calendar time in BDT of gpst, a calendar time in GPS time.
+func GPSTToBDT(gpst time.Time) time.Time {
+	return gpst.Add(-gpstMinusBDT)
+}
+
+// BDTToGPST returns the calendar time in GPS time of bdt, a calendar time in BDT.
+func BDTToGPST(bdt time.Time) time.Time {
+	return bdt.Add(gpstMinusBDT)
+}
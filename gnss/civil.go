@@ -0,0 +1,109 @@
+package gnss
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without a time zone. It has the same fields as
+// civil.Date of cloud.google.com/go/civil, so the two convert to each other
+// with type conversions, gnss.Date(d) and civil.Date(date), without gnss
+// depending on the package.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// TimeOfDay is a time of day without a date and a time zone. It converts
+// to and from civil.Time like Date.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// DateTime is a date and a time of day without a time zone. A
+// civil.DateTime dt is converted with
+//
+//	gnss.DateTime{Date: gnss.Date(dt.Date), Time: gnss.TimeOfDay(dt.Time)}
+//
+// and back likewise.
+type DateTime struct {
+	Date Date
+	Time TimeOfDay
+}
+
+// DateOf returns the date of t in its location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// DateTimeOf returns the date and the time of day of t in its location.
+func DateTimeOf(t time.Time) DateTime {
+	return DateTime{
+		Date: DateOf(t),
+		Time: TimeOfDay{t.Hour(), t.Minute(), t.Second(), t.Nanosecond()},
+	}
+}
+
+// In returns the time at 00:00 of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// IsValid reports whether d is a valid date.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// String returns d in the form of "yyyy-mm-dd".
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// YearDoy returns d in year and day of year.
+func (d Date) YearDoy() YearDoy {
+	return YearDoyOf(d.In(time.UTC))
+}
+
+// GNSSTime returns the GNSS week and the time of week at 00:00 of d in the
+// system time of sys, i.e. the week and the dow of d.
+func (d Date) GNSSTime(sys SatSys) (GNSSTime, error) {
+	return FromSystemTime(sys, d.In(time.UTC))
+}
+
+// In returns the time of dt in loc.
+func (dt DateTime) In(loc *time.Location) time.Time {
+	d, t := dt.Date, dt.Time
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// String returns dt in the form of "yyyy-mm-ddThh:mm:ss.fffffffff",
+// without trailing zeros of the fraction.
+func (dt DateTime) String() string {
+	return dt.In(time.UTC).Format("2006-01-02T15:04:05.999999999")
+}
+
+// GNSSTime returns dt, a time in UTC, in the week and the time of week of
+// sys. Use FromSystemTime(sys, dt.In(time.UTC)) for dt in the system time.
+func (dt DateTime) GNSSTime(sys SatSys) (GNSSTime, error) {
+	return TimeOf(sys, dt.In(time.UTC))
+}
+
+// DateOfWeek returns the date of week and dow (0 for Sunday) of sys.
+// It returns an error for systems whose week counting restarts
+// periodically (GLONASS), since the week number is ambiguous.
+func DateOfWeek(sys SatSys, week, dow int) (Date, error) {
+	s, ok := Lookup(string(sys))
+	if !ok {
+		return Date{}, unknownSatSys(sys)
+	}
+	if s.EpochFn != nil {
+		return Date{}, fmt.Errorf("week of %s is ambiguous", s.Name)
+	}
+	return DateOf(s.Epoch.AddDate(0, 0, 7*week+dow)), nil
+}
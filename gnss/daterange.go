@@ -0,0 +1,107 @@
+package gnss
+
+import (
+	"iter"
+	"time"
+)
+
+// DateRange is the dates from Start to End (inclusive) of UTC. The times
+// of day of Start and End are ignored.
+type DateRange struct {
+	Start, End time.Time
+}
+
+// Range returns the dates of w.
+func (w Week) Range() DateRange {
+	return DateRange{Start: w.Start, End: w.End}
+}
+
+// first and last return the dates of r at 00:00 UTC.
+func (r DateRange) first() time.Time { return utcDate(r.Start) }
+func (r DateRange) last() time.Time  { return utcDate(r.End) }
+
+// IsEmpty reports whether r has no date, i.e. End is before Start.
+func (r DateRange) IsEmpty() bool {
+	return r.last().Before(r.first())
+}
+
+// NumDays returns the number of dates of r.
+func (r DateRange) NumDays() int {
+	if r.IsEmpty() {
+		return 0
+	}
+	return int(r.last().Sub(r.first())/oneDay) + 1
+}
+
+// Contains reports whether the date of t in UTC is in r.
+func (r DateRange) Contains(t time.Time) bool {
+	d := utcDate(t)
+	return !d.Before(r.first()) && !d.After(r.last())
+}
+
+// Overlaps reports whether r and o have a date in common.
+func (r DateRange) Overlaps(o DateRange) bool {
+	if r.IsEmpty() || o.IsEmpty() {
+		return false
+	}
+	return !r.last().Before(o.first()) && !o.last().Before(r.first())
+}
+
+// Days returns an iterator over the dates of r annotated with GPS weeks;
+// see Days.
+func (r DateRange) Days() iter.Seq[Day] {
+	return Days(r.Start, r.End)
+}
+
+// SplitByWeek splits r at the boundaries of the GNSS weeks of sys,
+// including the restarts of week counting (GLONASS), e.g. to chunk
+// processing jobs by week. The first and the last ranges are clipped to r.
+// It returns an error if sys is unknown or r starts before its epoch.
+func (r DateRange) SplitByWeek(sys SatSys) ([]DateRange, error) {
+	s, ok := Lookup(string(sys))
+	if !ok {
+		return nil, unknownSatSys(sys)
+	}
+	if r.IsEmpty() {
+		return nil, nil
+	}
+	if epoch := s.EpochAt(r.first()); r.first().Before(epoch) {
+		return nil, beforeEpoch("%s is before the epoch of %s", r.first().Format("2006-01-02"), s.Name)
+	}
+
+	var rs []DateRange
+	for w := range Weeks(sys, r.Start, r.End) {
+		rs = append(rs, r.clip(w.Range()))
+	}
+	return rs, nil
+}
+
+// SplitByMonth splits r at the boundaries of months. The first and the
+// last ranges are clipped to r.
+func (r DateRange) SplitByMonth() []DateRange {
+	var rs []DateRange
+	for start := r.first(); !start.After(r.last()); {
+		next := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		rs = append(rs, r.clip(DateRange{Start: start, End: next.Add(-oneDay)}))
+		start = next
+	}
+	return rs
+}
+
+// clip returns the dates of o in r.
+func (r DateRange) clip(o DateRange) DateRange {
+	o.Start, o.End = o.first(), o.last()
+	if o.Start.Before(r.first()) {
+		o.Start = r.first()
+	}
+	if o.End.After(r.last()) {
+		o.End = r.last()
+	}
+	return o
+}
+
+// String returns r as an ISO 8601 interval of dates, e.g.
+// "2024-05-01/2024-05-31".
+func (r DateRange) String() string {
+	return r.first().Format("2006-01-02") + "/" + r.last().Format("2006-01-02")
+}
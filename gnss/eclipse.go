@@ -0,0 +1,138 @@
+package gnss
+
+import (
+	"math"
+	"time"
+)
+
+// OrbitPlane is a nominal orbital plane of a constellation, of which the
+// beta angle, the elevation of the sun above the plane, gives approximate
+// eclipse seasons.
+type OrbitPlane struct {
+	Sys           SatSys
+	Name          string    // e.g. "A"
+	Epoch         time.Time // epoch of RAAN
+	RAAN          float64   // right ascension of the ascending node at Epoch in degrees
+	Inclination   float64   // degrees
+	SemiMajorAxis float64   // meters
+}
+
+// constants of the eclipse model
+const (
+	earthRadius = 6378137.0      // m, WGS 84
+	earthGM     = 3.986004418e14 // m^3/s^2
+	earthJ2     = 1.08262668e-3
+)
+
+// orbitPlanes are the planes of the reference constellations: of the
+// baseline 24-slot constellation of GPS SPS PS at 1993-07-01, and of the
+// nominal constellation of Galileo OS SDD at 2016-01-01.
+var orbitPlanes = func() (planes []OrbitPlane) {
+	gps := time.Date(1993, time.July, 1, 0, 0, 0, 0, time.UTC)
+	for i, name := range []string{"A", "B", "C", "D", "E", "F"} {
+		planes = append(planes, OrbitPlane{SYSGPS, name, gps, math.Mod(272.847+60*float64(i), 360), 55, 26559.7e3})
+	}
+	gal := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i, name := range []string{"A", "B", "C"} {
+		planes = append(planes, OrbitPlane{SYSGAL, name, gal, math.Mod(317.632+120*float64(i), 360), 56, 29599.8e3})
+	}
+	return planes
+}()
+
+// OrbitPlanes returns the nominal orbital planes of sys; those of GPS (A
+// to F) and Galileo (A to C), or none for the other systems.
+func OrbitPlanes(sys SatSys) (planes []OrbitPlane) {
+	for _, p := range orbitPlanes {
+		if p.Sys == sys {
+			planes = append(planes, p)
+		}
+	}
+	return planes
+}
+
+// nodeRate returns the rate of the RAAN of p by J2 in degrees per day.
+func (p OrbitPlane) nodeRate() float64 {
+	n := math.Sqrt(earthGM / (p.SemiMajorAxis * p.SemiMajorAxis * p.SemiMajorAxis))
+	r := earthRadius / p.SemiMajorAxis
+	return -1.5 * n * earthJ2 * r * r * math.Cos(p.Inclination*deg) * 86400 / deg
+}
+
+// BetaAngle returns the beta angle of p at t in degrees, with the RAAN
+// regressing by J2 from Epoch and the low-precision position of the sun
+// of the Astronomical Almanac. It is approximate as the planes of the
+// satellites drift from the nominal ones, by a few degrees over years.
+func (p OrbitPlane) BetaAngle(t time.Time) float64 {
+	raan := (p.RAAN + p.nodeRate()*t.Sub(p.Epoch).Hours()/24) * deg
+	inc := p.Inclination * deg
+	normal := [3]float64{math.Sin(inc) * math.Sin(raan), -math.Sin(inc) * math.Cos(raan), math.Cos(inc)}
+
+	sun := sunDirection(t)
+	return math.Asin(normal[0]*sun[0]+normal[1]*sun[1]+normal[2]*sun[2]) / deg
+}
+
+// EclipseLimit returns the beta angle in degrees below which satellites of
+// p pass through the shadow of the earth in every orbit.
+func (p OrbitPlane) EclipseLimit() float64 {
+	return math.Asin(earthRadius/p.SemiMajorAxis) / deg
+}
+
+// InEclipseSeason reports whether the date of t in UTC is in an eclipse
+// season of p, i.e. the beta angle at 12:00 UTC is below EclipseLimit.
+func (p OrbitPlane) InEclipseSeason(t time.Time) bool {
+	return math.Abs(p.BetaAngle(utcDate(t).Add(12*time.Hour))) < p.EclipseLimit()
+}
+
+// EclipseSeason is an eclipse season of a plane: the dates of a low beta
+// angle, on which orbit products of the satellites are less accurate.
+type EclipseSeason struct {
+	Plane OrbitPlane
+	DateRange
+}
+
+// EclipseSeasons returns the eclipse seasons of the planes of sys
+// overlapping r, clipped to r and sorted by the start dates; see
+// InEclipseSeason.
+func EclipseSeasons(sys SatSys, r DateRange) []EclipseSeason {
+	var seasons []EclipseSeason
+	for date := r.first(); !date.After(r.last()); date = date.Add(oneDay) {
+		for _, p := range OrbitPlanes(sys) {
+			if !p.InEclipseSeason(date) {
+				continue
+			}
+			if i := lastSeason(seasons, p); i >= 0 && seasons[i].End.Equal(date.Add(-oneDay)) {
+				seasons[i].End = date
+				continue
+			}
+			seasons = append(seasons, EclipseSeason{Plane: p, DateRange: DateRange{Start: date, End: date}})
+		}
+	}
+	return seasons
+}
+
+// lastSeason returns the index of the last season of p in seasons, or -1.
+func lastSeason(seasons []EclipseSeason, p OrbitPlane) int {
+	for i := len(seasons) - 1; i >= 0; i-- {
+		if seasons[i].Plane == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// deg is a degree in radians.
+const deg = math.Pi / 180
+
+// sunDirection returns the unit vector to the sun at t in the equatorial
+// frame of date.
+func sunDirection(t time.Time) [3]float64 {
+	n := t.Sub(j2000).Hours() / 24
+	l := 280.460 + 0.9856474*n
+	g := (357.528 + 0.9856003*n) * deg
+	lambda := (l + 1.915*math.Sin(g) + 0.020*math.Sin(2*g)) * deg
+	eps := (23.439 - 0.0000004*n) * deg
+	return [3]float64{math.Cos(lambda), math.Cos(eps) * math.Sin(lambda), math.Sin(eps) * math.Sin(lambda)}
+}
+
+// j2000 is the epoch J2000.0, 2000-01-01 12:00 TT, which is taken as UTC
+// within the accuracy of sunDirection.
+var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
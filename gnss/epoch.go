@@ -0,0 +1,84 @@
+package gnss
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rinex2EpochPattern matches the beginning of epoch records of RINEX 2
+// observation files, the 2-digit year, month, day, hour, minute, and the
+// decimal seconds.
+var rinex2EpochPattern = regexp.MustCompile(`^ *[0-9]{1,2}( +[0-9]{1,2}){4} +[0-9]+\.[0-9]`)
+
+// isRinex2Epoch reports whether s begins with an epoch record of RINEX 2.
+func isRinex2Epoch(s string) bool {
+	return rinex2EpochPattern.MatchString(s)
+}
+
+// IsEpochRecord reports whether s is in the form of an epoch record of
+// RINEX 2/3 or SP3, which ParseTime parses by ParseEpochRecord.
+func IsEpochRecord(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, ">") || strings.HasPrefix(s, "*") || isRinex2Epoch(s)
+}
+
+// ParseEpochRecord parses an epoch record of RINEX 2/3 observation files or
+// an epoch header of SP3 files, and returns it as GNSSTime of sys. The epoch
+// is taken as a time in the time scale of sys, e.g.
+//
+//	> 2024 05 03 12 00 30.0000000  0 12    (RINEX 3)
+//	 24  5  3 12  0 30.0000000  0 12G01G02 (RINEX 2)
+//	*  2024  5  3 12  0  0.00000000        (SP3)
+//
+// Fields following the seconds, e.g. the epoch flag and the number of
+// satellites, are ignored.
+func ParseEpochRecord(sys SatSys, line string) (GNSSTime, error) {
+	// fields and their offsets in line, without the mark of the record
+	var buf fieldBuf
+	f, at := buf.split(line)
+	if len(f) > 0 && (f[0][0] == '>' || f[0][0] == '*') {
+		if len(f[0]) == 1 {
+			f, at = f[1:], at[1:]
+		} else {
+			f[0], at[0] = f[0][1:], at[0]+1
+		}
+	}
+	if len(f) < 6 {
+		return GNSSTime{}, parseError(line, "", -1, "invalid epoch record: '%s'", line)
+	}
+
+	var v [5]int
+	for i := range v {
+		n, err := strconv.Atoi(f[i])
+		if err != nil {
+			return GNSSTime{}, parseError(line, "", at[i], "invalid epoch record: '%s'", line)
+		}
+		v[i] = n
+	}
+	year, month, day, hour, minute := v[0], v[1], v[2], v[3], v[4]
+
+	// 2-digit year of RINEX 2
+	if len(f[0]) <= 2 {
+		year += 2000
+		if year >= 2080 {
+			year -= 100
+		}
+	}
+
+	sec, err := parseSeconds(f[5])
+	if err != nil || sec >= 61*time.Second {
+		return GNSSTime{}, parseError(line, "", at[5], "invalid seconds: '%s' in '%s'", f[5], line)
+	}
+	if month < 1 || 12 < month || day < 1 || 31 < day || 23 < hour || 59 < minute {
+		return GNSSTime{}, parseError(line, "", -1, "invalid epoch record: '%s'", line)
+	}
+
+	st := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC).Add(sec)
+	if st.Day() != day && sec < 60*time.Second {
+		return GNSSTime{}, parseError(line, "", -1, "invalid date in epoch record: '%s'", line)
+	}
+
+	return FromSystemTime(sys, st)
+}
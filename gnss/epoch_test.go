@@ -0,0 +1,30 @@
+package gnss
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimeEpochRecord(t *testing.T) {
+	want := date(2024, 5, 3).Add(12*time.Hour + 30*time.Second - 18*time.Second)
+	for _, s := range []string{
+		"> 2024 05 03 12 00 30.0000000  0 12",
+		" 24  5  3 12  0 30.0000000  0  8G01G02",
+		"*  2024  5  3 12  0 30.00000000",
+	} {
+		got, err := ParseTime(SYSGPS, s)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %s, %v, want %s", s, got, err, want)
+		}
+	}
+}
+
+func TestParseEpochRecordOffset(t *testing.T) {
+	line := "> 2099 05 03 12 00 99"
+	_, err := ParseEpochRecord(SYSGPS, line)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Offset != 19 {
+		t.Errorf("ParseEpochRecord(%q): error = %v, want the offset 19 of the seconds", line, err)
+	}
+}
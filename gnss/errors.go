@@ -0,0 +1,49 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors of the package, which can be tested with errors.Is.
+var (
+	ErrUnknownSatSys     = errors.New("unknown SatSys")
+	ErrBeforeSystemEpoch = errors.New("before the epoch of the system")
+)
+
+// ParseError describes a failure to parse Value. Offset is the position in
+// bytes of Value at which the error is found, or -1 if it is not known.
+type ParseError struct {
+	Value  string
+	Layout string // layout of Parse, or empty for the other forms
+	Offset int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return e.Msg
+}
+
+// parseError returns a ParseError with the message formatted.
+func parseError(value, layout string, offset int, format string, a ...interface{}) error {
+	return &ParseError{Value: value, Layout: layout, Offset: offset, Msg: fmt.Sprintf(format, a...)}
+}
+
+// wrapError is an error of the message msg, wrapping err for errors.Is.
+type wrapError struct {
+	msg string
+	err error
+}
+
+func (e *wrapError) Error() string { return e.msg }
+func (e *wrapError) Unwrap() error { return e.err }
+
+// unknownSatSys returns ErrUnknownSatSys of sys.
+func unknownSatSys(sys SatSys) error {
+	return fmt.Errorf("%w: '%s'", ErrUnknownSatSys, sys)
+}
+
+// beforeEpoch returns ErrBeforeSystemEpoch with the message msg.
+func beforeEpoch(format string, a ...interface{}) error {
+	return &wrapError{msg: fmt.Sprintf(format, a...), err: ErrBeforeSystemEpoch}
+}
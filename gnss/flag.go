@@ -0,0 +1,112 @@
+package gnss
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The types in this file implement flag.Value, and also the Type method
+// required by pflag.Value, so that CLIs using the library parse satellite
+// systems, dates and week/dow values with the same rules and messages.
+
+// String implements flag.Value.
+func (s *SatSys) String() string {
+	if s == nil {
+		return ""
+	}
+	return string(*s)
+}
+
+// Set implements flag.Value.
+// The value is parsed by ParseSatSys, e.g. "GPS", "G" or "Galileo".
+func (s *SatSys) Set(v string) error {
+	return s.UnmarshalText([]byte(v))
+}
+
+// Type implements pflag.Value.
+func (s *SatSys) Type() string {
+	return "satsys"
+}
+
+// SatSysVar defines a SatSys flag with specified name, default value and
+// usage string in fs. The argument p points to a SatSys variable in which
+// to store the value of the flag.
+func SatSysVar(fs *flag.FlagSet, p *SatSys, name string, value SatSys, usage string) {
+	*p = value
+	fs.Var(p, name, usage)
+}
+
+// DateValue is a flag.Value holding a date at 00:00 UTC.
+// The date is given as "yyyy-mm-dd" or "yyyy:ddd".
+type DateValue time.Time
+
+// String implements flag.Value.
+func (d *DateValue) String() string {
+	if d == nil || time.Time(*d).IsZero() {
+		return ""
+	}
+	return time.Time(*d).Format("2006-01-02")
+}
+
+// Set implements flag.Value.
+func (d *DateValue) Set(v string) error {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		*d = DateValue(t)
+		return nil
+	}
+	yd, err := ParseYearDoy(v)
+	if err != nil {
+		return fmt.Errorf("invalid date: '%s' (use yyyy-mm-dd or yyyy:ddd)", v)
+	}
+	*d = DateValue(yd.Time())
+	return nil
+}
+
+// Type implements pflag.Value.
+func (d *DateValue) Type() string {
+	return "date"
+}
+
+// DateVar defines a date flag with specified name, default value and usage
+// string in fs. The argument p points to a time.Time variable in which to
+// store the value of the flag.
+func DateVar(fs *flag.FlagSet, p *time.Time, name string, value time.Time, usage string) {
+	*p = value
+	fs.Var((*DateValue)(p), name, usage)
+}
+
+// Set implements flag.Value.
+// The value is given as "week:dow[:sow]" or "week:sow" in the system of g
+// (GPS if not set), or in the text form with a system, e.g. "GAL:1276:3600".
+func (g *GNSSTime) Set(v string) error {
+	if i := strings.IndexByte(v, ':'); i > 0 && (v[0] < '0' || v[0] > '9') {
+		return g.UnmarshalText([]byte(v))
+	}
+
+	sys := g.Sys
+	if sys == "" {
+		sys = SYSGPS
+	}
+	t, err := ParseWeekDowSow(sys, v)
+	if err != nil {
+		return err
+	}
+	*g = t
+	return nil
+}
+
+// Type implements pflag.Value.
+func (g *GNSSTime) Type() string {
+	return "weekdow"
+}
+
+// WeekDowVar defines a week/dow flag with specified name, default value and
+// usage string in fs. The argument p points to a GNSSTime variable in which
+// to store the value of the flag; the system of value is used for values
+// given without a system.
+func WeekDowVar(fs *flag.FlagSet, p *GNSSTime, name string, value GNSSTime, usage string) {
+	*p = value
+	fs.Var(p, name, usage)
+}
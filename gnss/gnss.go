@@ -0,0 +1,8 @@
+// Package gnss provides the satellite systems and time reckoning used by
+// the gnsscal command.
+//
+// Each satellite system is described by a SystemInfo registered in a
+// package level registry. The built-in systems (GPS, QZS, GAL, BDS, GLO,
+// and IRN) are registered on start up, and additional systems can be
+// registered at runtime with Register.
+package gnss
@@ -0,0 +1,176 @@
+// Package iers reads the Earth orientation parameters published by IERS
+// and provides UT1-UTC (DUT1) for a date.
+//
+// The package is optional; it is not imported by the gnss package, and is
+// used only when UT1 is needed. The data are read from the finals2000A
+// file (finals2000A.all, finals2000A.data, or finals2000A.daily) of the
+// IERS Rapid Service/Prediction Center, which contains Bulletin A values.
+package iers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mjdEpoch is the origin of modified Julian date.
+var mjdEpoch = time.Date(1858, time.November, 17, 0, 0, 0, 0, time.UTC)
+
+// Entry is UT1-UTC of a day at 00:00 UTC.
+type Entry struct {
+	MJD       int
+	DUT1      float64 // UT1-UTC in seconds
+	Predicted bool    // true for predicted values ('P' flag)
+}
+
+// Date returns the date of e.
+func (e Entry) Date() time.Time {
+	return mjdEpoch.AddDate(0, 0, e.MJD)
+}
+
+// Finals is a table of UT1-UTC read from a finals2000A file.
+type Finals struct {
+	Entries []Entry // sorted by MJD
+}
+
+// logger receives the diagnostics of the package. It discards them unless
+// SetLogger is called.
+var logger = slog.New(slog.DiscardHandler)
+
+// SetLogger sets the logger of the diagnostics of the package, which are
+// logged at the debug level. A nil l discards them.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(slog.DiscardHandler)
+	}
+	logger = l
+}
+
+// Load reads a finals2000A file from r.
+// Lines without UT1-UTC (far future) are skipped.
+func Load(r io.Reader) (*Finals, error) {
+	f := &Finals{}
+	sc := bufio.NewScanner(r)
+	n := 0
+	for sc.Scan() {
+		n++
+		line := sc.Text()
+		if len(line) < 68 || strings.TrimSpace(line[58:68]) == "" {
+			continue
+		}
+
+		mjd, err := strconv.ParseFloat(strings.TrimSpace(line[7:15]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MJD at line %d: '%s'", n, line[7:15])
+		}
+		dut1, err := strconv.ParseFloat(strings.TrimSpace(line[58:68]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UT1-UTC at line %d: '%s'", n, line[58:68])
+		}
+
+		f.Entries = append(f.Entries, Entry{
+			MJD:       int(math.Round(mjd)),
+			DUT1:      dut1,
+			Predicted: line[57] == 'P',
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(f.Entries) == 0 {
+		return nil, fmt.Errorf("no UT1-UTC found")
+	}
+
+	sort.Slice(f.Entries, func(i, j int) bool { return f.Entries[i].MJD < f.Entries[j].MJD })
+	predicted := 0
+	for _, e := range f.Entries {
+		if e.Predicted {
+			predicted++
+		}
+	}
+	logger.Debug("finals loaded", "entries", len(f.Entries), "predicted", predicted,
+		"first_mjd", f.Entries[0].MJD, "last_mjd", f.Entries[len(f.Entries)-1].MJD)
+	return f, nil
+}
+
+// LoadFile reads a finals2000A file.
+func LoadFile(path string) (*Finals, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	logger.Debug("loading finals", "path", path)
+	return Load(fp)
+}
+
+// FinalsURL is the finals2000A.all file of the IERS Rapid
+// Service/Prediction Center, updated daily.
+const FinalsURL = "https://datacenter.iers.org/data/9/finals2000A.all"
+
+// Fetch downloads a finals2000A file from url, e.g. FinalsURL, with
+// http.DefaultClient. The download is abandoned when ctx is done; give ctx
+// a deadline to bound it.
+func Fetch(ctx context.Context, url string) (*Finals, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	logger.Debug("finals fetched", "url", url, "status", resp.Status, "elapsed", time.Since(start))
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	return Load(resp.Body)
+}
+
+// DUT1 returns UT1-UTC at t (UTC), linearly interpolated between the daily
+// values. The jump of a leap second between two days is removed before the
+// interpolation. It returns an error if t is out of the table.
+func (f *Finals) DUT1(t time.Time) (time.Duration, error) {
+	t = t.UTC()
+	days := t.Sub(mjdEpoch).Hours() / 24
+	mjd := int(math.Floor(days))
+
+	i := sort.Search(len(f.Entries), func(i int) bool { return f.Entries[i].MJD > mjd })
+	if i == 0 || i == len(f.Entries) || f.Entries[i-1].MJD != mjd || f.Entries[i].MJD != mjd+1 {
+		return 0, fmt.Errorf("UT1-UTC is not available for %s", t.Format("2006-01-02"))
+	}
+
+	v0, v1 := f.Entries[i-1].DUT1, f.Entries[i].DUT1
+	if d := v1 - v0; d > 0.5 {
+		v1-- // leap second inserted at the end of the day
+		logger.Debug("leap second jump removed from interpolation", "mjd", mjd)
+	} else if d < -0.5 {
+		v1++
+		logger.Debug("leap second jump removed from interpolation", "mjd", mjd)
+	}
+	v := v0 + (v1-v0)*(days-float64(mjd))
+
+	return time.Duration(math.Round(v * 1e9)), nil
+}
+
+// UT1 returns the calendar time in UT1 of t (UTC).
+func (f *Finals) UT1(t time.Time) (time.Time, error) {
+	d, err := f.DUT1(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC().Add(d), nil
+}
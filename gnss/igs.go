@@ -0,0 +1,169 @@
+package gnss
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var igsACPattern = regexp.MustCompile(`^[a-z0-9]{3}$`)
+
+// BuildIGSLegacyName returns the legacy IGS product filename "acwwwwd.ext"
+// of analysis center ac (e.g. "igs", "cod", "esa") for the GPS week and day
+// of week of date, e.g. "igs23055.sp3". date is in GPS time, as the
+// products are named.
+//
+// For the ultra-rapid products of "igu", the hour of the update is appended
+// as "iguwwwwd_hh.ext", where hh is the hour of date truncated to 6 hours.
+func BuildIGSLegacyName(ac string, date time.Time, ext string) (string, error) {
+	ac = strings.ToLower(ac)
+	if !igsACPattern.MatchString(ac) {
+		return "", fmt.Errorf("invalid analysis center: '%s', analysis center must be 3 characters", ac)
+	}
+	if ext == "" {
+		return "", fmt.Errorf("empty extension")
+	}
+
+	g, err := FromSystemTime(SYSGPS, date.UTC())
+	if err != nil {
+		return "", err
+	}
+
+	if ac == "igu" {
+		return fmt.Sprintf("%s%04d%d_%02d.%s", ac, g.Week, g.Dow(), date.UTC().Hour()/6*6, ext), nil
+	}
+	return fmt.Sprintf("%s%04d%d.%s", ac, g.Week, g.Dow(), ext), nil
+}
+
+// BuildIGSLegacyWeeklyName returns the legacy IGS filename "acwwww7.ext" of
+// a weekly product (e.g. "erp" or "sum") of the GPS week containing date,
+// e.g. "igs23057.erp". date is in GPS time.
+func BuildIGSLegacyWeeklyName(ac string, date time.Time, ext string) (string, error) {
+	ac = strings.ToLower(ac)
+	if !igsACPattern.MatchString(ac) {
+		return "", fmt.Errorf("invalid analysis center: '%s', analysis center must be 3 characters", ac)
+	}
+	if ext == "" {
+		return "", fmt.Errorf("empty extension")
+	}
+
+	g, err := FromSystemTime(SYSGPS, date.UTC())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%04d7.%s", ac, g.Week, ext), nil
+}
+
+// IGSProductName holds the fields of an IGS long product filename, e.g.
+// "IGS0OPSFIN_20243350000_01D_15M_ORB.SP3.gz".
+type IGSProductName struct {
+	AC          string    // analysis center, e.g. "IGS", "COD"
+	Version     int       // version of the product, 0-9
+	Campaign    string    // project or campaign, e.g. "OPS", "MGX", "R03"
+	Solution    string    // solution type, e.g. "FIN", "RAP", "ULT"
+	Start       time.Time // start time of the product
+	Period      string    // intended period of the product, e.g. "01D", "07D"
+	Sampling    string    // sampling interval, e.g. "15M", "30S"; "00U" if not applicable
+	Content     string    // content type, e.g. "ORB", "CLK", "ERP"
+	Format      string    // file format, e.g. "SP3", "CLK", "ERP"
+	Compression string    // e.g. "gz"; empty if not compressed
+}
+
+var (
+	igsLongACPattern       = regexp.MustCompile(`^[A-Z0-9]{3}$`)
+	igsLongDurationPattern = regexp.MustCompile(`^[0-9]{2}[SMHDWLYU]$`)
+	igsLongNamePattern     = regexp.MustCompile(`^([A-Z0-9]{3})([0-9])([A-Z0-9]{3})([A-Z]{3})_([0-9]{4})([0-9]{3})([0-9]{2})([0-9]{2})_([0-9]{2}[SMHDWLYU])_([0-9]{2}[SMHDWLYU])_([A-Z]{3})\.([A-Z0-9]{3})(?:\.(Z|gz|bz2|zip))?$`)
+)
+
+// Validate reports whether the fields of the filename are valid.
+func (n IGSProductName) Validate() error {
+	if !igsLongACPattern.MatchString(n.AC) {
+		return fmt.Errorf("invalid analysis center: '%s', analysis center must be 3 characters, e.g. IGS", n.AC)
+	}
+	if n.Version < 0 || 9 < n.Version {
+		return fmt.Errorf("invalid version: %d", n.Version)
+	}
+	if !igsLongACPattern.MatchString(n.Campaign) {
+		return fmt.Errorf("invalid campaign: '%s', campaign must be 3 characters, e.g. OPS", n.Campaign)
+	}
+	if !igsLongACPattern.MatchString(n.Solution) {
+		return fmt.Errorf("invalid solution type: '%s', solution type must be 3 characters, e.g. FIN", n.Solution)
+	}
+	if !igsLongDurationPattern.MatchString(n.Period) {
+		return fmt.Errorf("invalid period: '%s', period must be like 01D or 07D", n.Period)
+	}
+	if !igsLongDurationPattern.MatchString(n.Sampling) {
+		return fmt.Errorf("invalid sampling: '%s', sampling must be like 15M or 30S", n.Sampling)
+	}
+	if !igsLongACPattern.MatchString(n.Content) {
+		return fmt.Errorf("invalid content type: '%s', content type must be 3 characters, e.g. ORB", n.Content)
+	}
+	if !igsLongACPattern.MatchString(n.Format) {
+		return fmt.Errorf("invalid format: '%s', format must be 3 characters, e.g. SP3", n.Format)
+	}
+	switch n.Compression {
+	case "", "gz", "bz2", "zip", "Z":
+	default:
+		return fmt.Errorf("invalid compression: '%s'", n.Compression)
+	}
+
+	return nil
+}
+
+// String returns the filename without validation.
+func (n IGSProductName) String() string {
+	t := n.Start.UTC()
+	name := fmt.Sprintf("%s%d%s%s_%04d%03d%02d%02d_%s_%s_%s.%s",
+		n.AC, n.Version, n.Campaign, n.Solution, t.Year(), t.YearDay(), t.Hour(), t.Minute(), n.Period, n.Sampling, n.Content, n.Format)
+	if n.Compression != "" {
+		name += "." + n.Compression
+	}
+	return name
+}
+
+// BuildIGSProductName validates n and returns the IGS long product filename.
+func BuildIGSProductName(n IGSProductName) (string, error) {
+	if err := n.Validate(); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// ParseIGSProductName parses an IGS long product filename.
+// The directory part of name is ignored.
+func ParseIGSProductName(name string) (IGSProductName, error) {
+	base := filepath.Base(name)
+	m := igsLongNamePattern.FindStringSubmatch(base)
+	if m == nil {
+		return IGSProductName{}, fmt.Errorf("not an IGS long product filename: %s", base)
+	}
+
+	version, _ := strconv.Atoi(m[2])
+	year, _ := strconv.Atoi(m[5])
+	doy, _ := strconv.Atoi(m[6])
+	hour, _ := strconv.Atoi(m[7])
+	minute, _ := strconv.Atoi(m[8])
+	if !validDoy(year, doy) {
+		return IGSProductName{}, fmt.Errorf("invalid doy: %03d in %s", doy, base)
+	}
+	if hour > 23 || minute > 59 {
+		return IGSProductName{}, fmt.Errorf("invalid start time: %s%s in %s", m[7], m[8], base)
+	}
+
+	return IGSProductName{
+		AC:          m[1],
+		Version:     version,
+		Campaign:    m[3],
+		Solution:    m[4],
+		Start:       time.Date(year, time.January, doy, hour, minute, 0, 0, time.UTC),
+		Period:      m[9],
+		Sampling:    m[10],
+		Content:     m[11],
+		Format:      m[12],
+		Compression: m[13],
+	}, nil
+}
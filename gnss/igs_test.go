@@ -0,0 +1,36 @@
+package gnss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildIGSLegacyName(t *testing.T) {
+	tests := []struct {
+		ac   string
+		date time.Time
+		want string
+	}{
+		{"igs", date(1980, 1, 6), "igs00000.sp3"},
+		{"igs", date(2024, 3, 14), "igs23054.sp3"},
+		// the last seconds of a GPS day are not of the next day by leap seconds
+		{"igs", date(2019, 4, 6).Add(24*time.Hour - 10*time.Second), "igs20476.sp3"},
+		{"igu", date(2024, 3, 14).Add(23*time.Hour + 59*time.Minute + 50*time.Second), "igu23054_18.sp3"},
+	}
+	for _, tt := range tests {
+		got, err := BuildIGSLegacyName(tt.ac, tt.date, "sp3")
+		if err != nil || got != tt.want {
+			t.Errorf("BuildIGSLegacyName(%s, %s) = %q, %v, want %q", tt.ac, tt.date, got, err, tt.want)
+		}
+	}
+	if _, err := BuildIGSLegacyName("igs", date(1980, 1, 5), "sp3"); err == nil {
+		t.Errorf("BuildIGSLegacyName(igs, 1980-01-05): no error")
+	}
+}
+
+func TestBuildIGSLegacyWeeklyName(t *testing.T) {
+	got, err := BuildIGSLegacyWeeklyName("igs", date(2019, 4, 6).Add(24*time.Hour-time.Second), "erp")
+	if want := "igs20477.erp"; err != nil || got != want {
+		t.Errorf("BuildIGSLegacyWeeklyName = %q, %v, want %q", got, err, want)
+	}
+}
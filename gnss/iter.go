@@ -0,0 +1,102 @@
+package gnss
+
+import (
+	"iter"
+	"time"
+)
+
+// Day is a date annotated with its day of year and GNSS week and day of
+// week of Sys.
+type Day struct {
+	Sys  SatSys
+	Date time.Time // 00:00 UTC
+	Doy  int
+	Week int // -1 before the epoch of Sys
+	Dow  int // 0 for Sunday, or -1 before the epoch of Sys
+}
+
+// Week is a GNSS week of Sys from Start to End (inclusive), the dates at
+// 00:00 UTC. Weeks cut short by the restart of week counting (GLONASS)
+// end on the day before the restart.
+type Week struct {
+	Sys        SatSys
+	Week       int
+	Start, End time.Time
+}
+
+// Days returns an iterator over the dates from from to to (inclusive) of
+// UTC, annotated with GPS weeks and days of week. Use Week.Days for the
+// weeks of other systems.
+//
+//	for d := range gnss.Days(from, to) {
+//		fmt.Println(d.Date.Format("2006-01-02"), d.Doy, d.Week, d.Dow)
+//	}
+func Days(from, to time.Time) iter.Seq[Day] {
+	return systemDays(SYSGPS, from, to)
+}
+
+// Days returns an iterator over the days of w.
+func (w Week) Days() iter.Seq[Day] {
+	return systemDays(w.Sys, w.Start, w.End)
+}
+
+// systemDays returns an iterator over the dates from from to to
+// (inclusive) annotated with weeks of sys.
+func systemDays(sys SatSys, from, to time.Time) iter.Seq[Day] {
+	return func(yield func(Day) bool) {
+		last := utcDate(to)
+		for date := utcDate(from); !date.After(last); date = date.Add(oneDay) {
+			d := Day{Sys: sys, Date: date, Doy: date.YearDay(), Week: -1, Dow: -1}
+			if g, err := FromSystemTime(sys, date); err == nil {
+				d.Week, d.Dow = g.Week, g.Dow()
+			}
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Weeks returns an iterator over the GNSS weeks of sys touching the dates
+// from from to to (inclusive) of UTC; the first may start before from and
+// the last end after to. Weeks before the epoch of sys are skipped, and
+// none are yielded for unknown systems.
+func Weeks(sys SatSys, from, to time.Time) iter.Seq[Week] {
+	return func(yield func(Week) bool) {
+		if _, ok := Lookup(string(sys)); !ok {
+			return
+		}
+
+		last := utcDate(to)
+		var w Week
+		found := false
+		for date := utcDate(from); ; date = date.Add(oneDay) {
+			g, err := FromSystemTime(sys, date)
+			if err != nil {
+				if date.After(last) {
+					return
+				}
+				continue // before the epoch
+			}
+			if found && g.Week == w.Week {
+				w.End = date
+				continue
+			}
+
+			// a new week starts at date
+			if found && !yield(w) {
+				return
+			}
+			if date.After(last) {
+				return
+			}
+			w = Week{Sys: sys, Week: g.Week, Start: date.Add(-time.Duration(g.Dow()) * oneDay), End: date}
+			found = true
+		}
+	}
+}
+
+// utcDate returns the date of t in UTC at 00:00.
+func utcDate(t time.Time) time.Time {
+	return t.UTC().Truncate(oneDay)
+}
@@ -0,0 +1,50 @@
+package gnss
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProductTier is a tier of IGS orbit and clock products.
+type ProductTier string
+
+const (
+	TierUltraRapid ProductTier = "ultra-rapid"
+	TierRapid      ProductTier = "rapid"
+	TierFinal      ProductTier = "final"
+)
+
+// ProductTiers are the tiers of IGS products in order of latency.
+var ProductTiers = []ProductTier{TierUltraRapid, TierRapid, TierFinal}
+
+// ProductAvailability returns the estimated time at which the products of
+// tier covering the whole day of date become available, based on the
+// standard IGS latency rules:
+//
+//	ultra-rapid  issued four times a day at 03, 09, 15 and 21 UTC; the
+//	             observed half of the issue at 03 UTC of the next day
+//	             covers the day
+//	rapid        released daily at 17 UTC of the next day
+//	final        released weekly on Thursday, 11 days after the end of
+//	             the GPS week (12-18 days latency)
+func ProductAvailability(tier ProductTier, date time.Time) (time.Time, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	next := day.Add(oneDay)
+
+	switch tier {
+	case TierUltraRapid:
+		return next.Add(3 * time.Hour), nil
+	case TierRapid:
+		return next.Add(17 * time.Hour), nil
+	case TierFinal:
+		g, err := TimeOf(SYSGPS, day)
+		if err != nil {
+			return time.Time{}, err
+		}
+		// by days, as a time.Duration of weeks overflows beyond 2262
+		weekEnd := GPST0.AddDate(0, 0, 7*(g.Week+1))
+		return weekEnd.AddDate(0, 0, 11), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unknown product tier: '%s'", tier)
+}
@@ -0,0 +1,36 @@
+package gnss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProductAvailability(t *testing.T) {
+	tests := []struct {
+		tier ProductTier
+		date time.Time
+		want time.Time
+	}{
+		{TierUltraRapid, date(2024, 5, 2).Add(15 * time.Hour), date(2024, 5, 3).Add(3 * time.Hour)},
+		{TierRapid, date(2024, 5, 2), date(2024, 5, 3).Add(17 * time.Hour)},
+		// GPS week 2312 ends on 2024-05-05
+		{TierFinal, date(2024, 4, 28), date(2024, 5, 16)},
+		{TierFinal, date(2024, 5, 4), date(2024, 5, 16)},
+		{TierFinal, date(2024, 5, 5), date(2024, 5, 23)},
+		// weeks beyond 2262, where a time.Duration from GPST0 overflows
+		{TierFinal, date(2300, 1, 1), date(2300, 1, 18)},
+	}
+	for _, tt := range tests {
+		got, err := ProductAvailability(tt.tier, tt.date)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ProductAvailability(%s, %v) = %v, %v, want %v", tt.tier, tt.date, got, err, tt.want)
+		}
+	}
+
+	if _, err := ProductAvailability("daily", date(2024, 5, 2)); err == nil {
+		t.Errorf("ProductAvailability(daily) succeeded, want error")
+	}
+	if _, err := ProductAvailability(TierFinal, date(1980, 1, 5)); err == nil {
+		t.Errorf("ProductAvailability(final, 1980-01-05) succeeded, want error")
+	}
+}
@@ -0,0 +1,333 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Layout verbs of Format and Parse:
+//
+//	%Y  4-digit year           %W  4-digit GNSS week
+//	%y  2-digit year           %N  GNSS day of week (0-6)
+//	%m  2-digit month          %S  seconds of week
+//	%d  2-digit day            %X  seconds of day
+//	%j  3-digit day of year    %M  modified Julian date
+//	%H  2-digit hour           %i  2-digit minute
+//	%s  2-digit second         %U  Unix time in seconds
+//	%f  9-digit nanoseconds    %%  '%'
+//
+// The calendar verbs are of UTC, and the GNSS verbs (%W, %N, and %S) are of
+// the system time of sys.
+
+// mjdEpoch is the origin of modified Julian date.
+var mjdEpoch = time.Date(1858, time.November, 17, 0, 0, 0, 0, time.UTC)
+
+// MJD returns the modified Julian date of the day of t.
+func MJD(t time.Time) int {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return DaysBetween(mjdEpoch, day)
+}
+
+// FractionalMJD returns the modified Julian date of t with the fraction of
+// the day, e.g. 60432.5 for 2024-05-02 12:00 UTC.
+func FractionalMJD(t time.Time) float64 {
+	t = t.UTC()
+	return float64(MJD(t)) + float64(t.Sub(t.Truncate(oneDay)))/float64(oneDay)
+}
+
+// FromMJD returns the time in UTC of the modified Julian date mjd with the
+// fraction of the day. The fraction of a float64 MJD resolves about a
+// microsecond, to which the time is rounded.
+func FromMJD(mjd float64) time.Time {
+	day := math.Floor(mjd)
+	frac := time.Duration(math.Round((mjd-day)*float64(oneDay)/1e3)) * time.Microsecond
+	return mjdEpoch.AddDate(0, 0, int(day)).Add(frac)
+}
+
+// ParseMJD parses a modified Julian date with an optional fraction of the
+// day, e.g. "60432.5", and returns the time in UTC; see FromMJD.
+func ParseMJD(s string) (time.Time, error) {
+	mjd, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(mjd, 0) || math.IsNaN(mjd) || math.Abs(mjd) > 1e7 {
+		return time.Time{}, parseError(s, "", -1, "invalid MJD: '%s'", s)
+	}
+	return FromMJD(mjd), nil
+}
+
+// Format returns a textual representation of t formatted according to layout.
+func Format(layout string, sys SatSys, t time.Time) (string, error) {
+	var buf [64]byte
+	b, err := AppendFormat(buf[:0], layout, sys, t)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// AppendFormat is like Format but appends the textual representation to
+// dst and returns the extended buffer. It does not allocate if dst has
+// enough capacity, for converting many epochs in a batch.
+func AppendFormat(dst []byte, layout string, sys SatSys, t time.Time) ([]byte, error) {
+	t = t.UTC()
+	year, month, mday := t.Date()
+	hour, min, sec := t.Clock()
+
+	// the GNSS time is computed at the first of %W, %N and %S, so that
+	// layouts without them format dates before the epoch of sys
+	var g GNSSTime
+	var haveG bool
+
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i == len(layout)-1 {
+			dst = append(dst, layout[i])
+			continue
+		}
+		i++
+		switch layout[i] {
+		case 'Y':
+			dst = appendInt(dst, year, 4)
+		case 'y':
+			dst = appendInt(dst, year%100, 2)
+		case 'm':
+			dst = appendInt(dst, int(month), 2)
+		case 'd':
+			dst = appendInt(dst, mday, 2)
+		case 'j':
+			dst = appendInt(dst, t.YearDay(), 3)
+		case 'H':
+			dst = appendInt(dst, hour, 2)
+		case 'i':
+			dst = appendInt(dst, min, 2)
+		case 's':
+			dst = appendInt(dst, sec, 2)
+		case 'f':
+			dst = appendInt(dst, t.Nanosecond(), 9)
+		case 'W', 'N', 'S':
+			if !haveG {
+				var err error
+				if g, err = TimeOf(sys, t); err != nil {
+					return dst, err
+				}
+				haveG = true
+			}
+			switch layout[i] {
+			case 'W':
+				dst = appendInt(dst, g.Week, 4)
+			case 'N':
+				dst = appendInt(dst, g.Dow(), 1)
+			case 'S':
+				dst = appendInt(dst, int(g.Sow/time.Second), 1)
+			}
+		case 'X':
+			dst = appendInt(dst, hour*3600+min*60+sec, 1)
+		case 'M':
+			dst = appendInt(dst, MJD(t), 1)
+		case 'U':
+			dst = strconv.AppendInt(dst, t.Unix(), 10)
+		case '%':
+			dst = append(dst, '%')
+		default:
+			return dst, fmt.Errorf("unknown verb: '%%%c' in layout '%s'", layout[i], layout)
+		}
+	}
+
+	return dst, nil
+}
+
+// appendInt appends n zero-padded to width digits, as "%0*d" does.
+func appendInt(dst []byte, n, width int) []byte {
+	if n < 0 {
+		dst = append(dst, '-')
+		n = -n
+		width--
+	}
+	var buf [20]byte
+	i := len(buf)
+	for n >= 10 || i > len(buf)-width+1 {
+		i--
+		buf[i] = byte('0' + n%10)
+		n /= 10
+	}
+	i--
+	buf[i] = byte('0' + n)
+	return append(dst, buf[i:]...)
+}
+
+// widths of verbs; 0 for variable width
+var verbWidths = map[byte]int{
+	'Y': 4, 'y': 2, 'm': 2, 'd': 2, 'j': 3, 'H': 2, 'i': 2, 's': 2, 'f': 9,
+	'W': 4, 'N': 1, 'S': 0, 'X': 0, 'M': 0, 'U': 0,
+}
+
+// maxima of the verbs of the time of day
+var clockMax = [...]struct {
+	verb byte
+	max  int
+}{{'H', 23}, {'i', 59}, {'s', 60}}
+
+// Parse parses s according to layout and returns the time in UTC.
+//
+// The time is determined by %U if given. Otherwise the date is determined
+// by %W (with %N and %S) if given, by %M, by %Y and
+// %j, or by %Y, %m, and %d, in this order. The time of day is given by %H,
+// %i, and %s, or by %X, and the nanoseconds by %f. If %W is given without
+// %S, the day of the GNSS week is returned as a date at 00:00 UTC.
+//
+// Errors of s are *ParseError with the offset of the value in s.
+func Parse(layout string, sys SatSys, s string) (time.Time, error) {
+	// values and offsets in s of the verbs given, indexed by verb
+	var v, pos [128]int
+	var given [128]bool
+	j := 0
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i == len(layout)-1 {
+			if j >= len(s) || s[j] != layout[i] {
+				return time.Time{}, parseError(s, layout, j, "'%s' does not match layout '%s'", s, layout)
+			}
+			j++
+			continue
+		}
+
+		i++
+		verb := layout[i]
+		if verb == '%' {
+			if j >= len(s) || s[j] != '%' {
+				return time.Time{}, parseError(s, layout, j, "'%s' does not match layout '%s'", s, layout)
+			}
+			j++
+			continue
+		}
+		w, ok := verbWidths[verb]
+		if !ok || verb >= 128 {
+			return time.Time{}, parseError(s, layout, -1, "unknown verb: '%%%c' in layout '%s'", verb, layout)
+		}
+
+		// read digits
+		k := j
+		for k < len(s) && '0' <= s[k] && s[k] <= '9' && (w == 0 || k-j < w) {
+			k++
+		}
+		if k == j || (w > 0 && k-j != w) {
+			return time.Time{}, parseError(s, layout, j, "invalid value of '%%%c' at %d of '%s'", verb, j, s)
+		}
+		n, err := strconv.Atoi(s[j:k])
+		if err != nil {
+			return time.Time{}, parseError(s, layout, j, "invalid value of '%%%c' at %d of '%s'", verb, j, s)
+		}
+		v[verb] = n
+		pos[verb] = j
+		given[verb] = true
+		j = k
+	}
+	if j != len(s) {
+		return time.Time{}, parseError(s, layout, j, "extra text '%s' after layout '%s'", s[j:], layout)
+	}
+
+	has := func(verbs string) bool {
+		for i := 0; i < len(verbs); i++ {
+			if !given[verbs[i]] {
+				return false
+			}
+		}
+		return true
+	}
+	// at returns the offset of the value of verb in s, or -1
+	at := func(verb byte) int {
+		if given[verb] {
+			return pos[verb]
+		}
+		return -1
+	}
+
+	// Unix time
+	if has("U") {
+		if debugEnabled() {
+			logger.Debug("parsing layout", "input", s, "layout", layout, "date_by", "%U")
+		}
+		return time.Unix(int64(v['U']), int64(v['f'])).UTC(), nil
+	}
+
+	// GNSS week
+	if has("W") {
+		if debugEnabled() {
+			logger.Debug("parsing layout", "input", s, "layout", layout, "date_by", "%W", "satsys", sys, "sow_given", has("S"))
+		}
+		if v['N'] > 6 {
+			return time.Time{}, parseError(s, layout, at('N'), "invalid time of week in '%s'", s)
+		}
+		if time.Duration(v['S'])*time.Second >= oneWeek {
+			return time.Time{}, parseError(s, layout, at('S'), "invalid time of week in '%s'", s)
+		}
+		if !has("S") {
+			info, ok := Lookup(string(sys))
+			if !ok {
+				return time.Time{}, unknownSatSys(sys)
+			}
+			if info.EpochFn != nil {
+				return time.Time{}, fmt.Errorf("week of %s is ambiguous", info.Name)
+			}
+			return info.Epoch.AddDate(0, 0, 7*v['W']+v['N']), nil
+		}
+		return GNSSTime{Sys: sys, Week: v['W'], Sow: time.Duration(v['S'])*time.Second + time.Duration(v['f'])}.Time()
+	}
+
+	// year
+	year := v['Y']
+	if !has("Y") && has("y") {
+		year = 2000 + v['y']
+		if v['y'] >= 80 {
+			year = 1900 + v['y']
+		}
+		if debugEnabled() {
+			logger.Debug("2-digit year expanded", "input", s, "year", year)
+		}
+	}
+
+	// date
+	var day time.Time
+	switch {
+	case has("M"):
+		if debugEnabled() {
+			logger.Debug("parsing layout", "input", s, "layout", layout, "date_by", "%M")
+		}
+		day = mjdEpoch.AddDate(0, 0, v['M'])
+	case (has("Y") || has("y")) && has("j"):
+		if debugEnabled() {
+			logger.Debug("parsing layout", "input", s, "layout", layout, "date_by", "year and %j")
+		}
+		if !validDoy(year, v['j']) {
+			return time.Time{}, parseError(s, layout, at('j'), "invalid doy: %d in '%s'", v['j'], s)
+		}
+		day = time.Date(year, time.January, v['j'], 0, 0, 0, 0, time.UTC)
+	case (has("Y") || has("y")) && has("m") && has("d"):
+		if debugEnabled() {
+			logger.Debug("parsing layout", "input", s, "layout", layout, "date_by", "year, %m and %d")
+		}
+		day = time.Date(year, time.Month(v['m']), v['d'], 0, 0, 0, 0, time.UTC)
+		if v['m'] < 1 || 12 < v['m'] {
+			return time.Time{}, parseError(s, layout, at('m'), "invalid date in '%s'", s)
+		}
+		if day.Day() != v['d'] {
+			return time.Time{}, parseError(s, layout, at('d'), "invalid date in '%s'", s)
+		}
+	default:
+		return time.Time{}, parseError(s, layout, -1, "layout '%s' does not specify a date", layout)
+	}
+
+	// time of day
+	if has("X") {
+		if v['X'] >= 86400 {
+			return time.Time{}, parseError(s, layout, at('X'), "invalid seconds of day: %d in '%s'", v['X'], s)
+		}
+		return day.Add(time.Duration(v['X'])*time.Second + time.Duration(v['f'])), nil
+	}
+	for _, c := range clockMax {
+		if v[c.verb] > c.max {
+			return time.Time{}, parseError(s, layout, at(c.verb), "invalid time of day in '%s'", s)
+		}
+	}
+	return day.Add(time.Duration(v['H'])*time.Hour + time.Duration(v['i'])*time.Minute + time.Duration(v['s'])*time.Second + time.Duration(v['f'])), nil
+}
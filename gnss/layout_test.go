@@ -0,0 +1,16 @@
+package gnss
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatBeforeEpoch(t *testing.T) {
+	d := date(1995, 3, 1)
+	if got, err := Format("%Y-%m-%d doy %j MJD %M", SYSGAL, d); err != nil || got != "1995-03-01 doy 060 MJD 49777" {
+		t.Errorf("Format of a date before the epoch of GAL = %q, %v", got, err)
+	}
+	if _, err := Format("%Y %W", SYSGAL, d); !errors.Is(err, ErrBeforeSystemEpoch) {
+		t.Errorf("Format of %%W before the epoch of GAL: error = %v, want ErrBeforeSystemEpoch", err)
+	}
+}
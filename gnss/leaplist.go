@@ -0,0 +1,71 @@
+package gnss
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ntpEpoch is the origin of NTP timestamps used in leap-seconds.list.
+var ntpEpoch = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ParseLeapSecondsList parses the leap-seconds.list file distributed by
+// IERS and IANA, and returns the leap seconds and the expiration date of
+// the file. The offset from 1972 (10 s) is not included as a leap second.
+func ParseLeapSecondsList(r io.Reader) (ls []LeapSecond, expire time.Time, err error) {
+	sc := bufio.NewScanner(r)
+	n := 0
+	first := true
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "#@") {
+			f := strings.Fields(line[2:])
+			if len(f) == 0 {
+				return nil, expire, fmt.Errorf("invalid expiration at line %d", n)
+			}
+			sec, err := strconv.ParseInt(f[0], 10, 64)
+			if err != nil {
+				return nil, expire, fmt.Errorf("invalid expiration at line %d: '%s'", n, f[0])
+			}
+			expire = ntpEpoch.Add(time.Duration(sec) * time.Second)
+			continue
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		f := strings.Fields(line)
+		if len(f) == 0 {
+			continue
+		}
+		if len(f) != 2 {
+			return nil, expire, fmt.Errorf("invalid entry at line %d: '%s'", n, line)
+		}
+
+		sec, err1 := strconv.ParseInt(f[0], 10, 64)
+		taiUTC, err2 := strconv.Atoi(f[1])
+		if err1 != nil || err2 != nil {
+			return nil, expire, fmt.Errorf("invalid entry at line %d: '%s'", n, line)
+		}
+		if first {
+			first = false
+			continue // the offset from 1972
+		}
+		ls = append(ls, LeapSecond{
+			Date:        ntpEpoch.Add(time.Duration(sec)*time.Second).AddDate(0, 0, -1),
+			TAIMinusUTC: time.Duration(taiUTC) * time.Second,
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, expire, err
+	}
+	if expire.IsZero() {
+		return nil, expire, fmt.Errorf("expiration date not found")
+	}
+
+	logger.Debug("leap-seconds.list parsed", "leap_seconds", len(ls), "expire", expire.Format("2006-01-02"))
+	return ls, expire, nil
+}
@@ -0,0 +1,116 @@
+package gnss
+
+import "time"
+
+// leapSecond is an entry of the TAI-UTC table.
+type leapSecond struct {
+	date   time.Time // the date from which TAI-UTC is applied
+	taiUTC int       // TAI-UTC in seconds
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// leapSeconds is the table of TAI-UTC since 1972.
+var leapSeconds = []leapSecond{
+	{date(1972, time.January, 1), 10},
+	{date(1972, time.July, 1), 11},
+	{date(1973, time.January, 1), 12},
+	{date(1974, time.January, 1), 13},
+	{date(1975, time.January, 1), 14},
+	{date(1976, time.January, 1), 15},
+	{date(1977, time.January, 1), 16},
+	{date(1978, time.January, 1), 17},
+	{date(1979, time.January, 1), 18},
+	{date(1980, time.January, 1), 19},
+	{date(1981, time.July, 1), 20},
+	{date(1982, time.July, 1), 21},
+	{date(1983, time.July, 1), 22},
+	{date(1985, time.July, 1), 23},
+	{date(1988, time.January, 1), 24},
+	{date(1990, time.January, 1), 25},
+	{date(1991, time.January, 1), 26},
+	{date(1992, time.July, 1), 27},
+	{date(1993, time.July, 1), 28},
+	{date(1994, time.July, 1), 29},
+	{date(1996, time.January, 1), 30},
+	{date(1997, time.July, 1), 31},
+	{date(1999, time.January, 1), 32},
+	{date(2006, time.January, 1), 33},
+	{date(2009, time.January, 1), 34},
+	{date(2012, time.July, 1), 35},
+	{date(2015, time.July, 1), 36},
+	{date(2017, time.January, 1), 37},
+}
+
+// leapUnix is the dates of leapSeconds in Unix time, searched by
+// TAIMinusUTC without the comparisons of time.Time.
+var leapUnix = func() []int64 {
+	u := make([]int64, len(leapSeconds))
+	for i, l := range leapSeconds {
+		u[i] = l.date.Unix()
+	}
+	return u
+}()
+
+// leapSecondsExpire is the date until which the table is known to be valid,
+// as announced by IERS Bulletin C.
+var leapSecondsExpire = date(2026, time.June, 28)
+
+// LeapSecond is a leap second inserted at 23:59:60 UTC of Date.
+type LeapSecond struct {
+	Date        time.Time     // the day at the end of which the leap second is inserted
+	TAIMinusUTC time.Duration // TAI-UTC after the leap second
+}
+
+// LeapSeconds returns all leap seconds in the table in chronological order.
+// The initial offset of 1972 (10 s) is not a leap second and is not included.
+func LeapSeconds() []LeapSecond {
+	ls := make([]LeapSecond, 0, len(leapSeconds)-1)
+	for _, l := range leapSeconds[1:] {
+		ls = append(ls, LeapSecond{
+			Date:        l.date.AddDate(0, 0, -1),
+			TAIMinusUTC: time.Duration(l.taiUTC) * time.Second,
+		})
+	}
+	return ls
+}
+
+// LeapSecondsExpire returns the date until which the leap second table is
+// known to be valid.
+func LeapSecondsExpire() time.Time {
+	return leapSecondsExpire
+}
+
+// NextLeapSecond returns the date of the first leap second announced after t.
+// ok is false if no leap second is announced in the table. The date until
+// which the table is valid is returned as expire.
+func NextLeapSecond(t time.Time) (next time.Time, expire time.Time, ok bool) {
+	for _, l := range leapSeconds {
+		if l.date.After(t) {
+			return l.date, leapSecondsExpire, true
+		}
+	}
+	return time.Time{}, leapSecondsExpire, false
+}
+
+// TAIMinusUTC returns TAI-UTC at t (UTC).
+// The value of 1972 (10 s) is returned for t before 1972.
+func TAIMinusUTC(t time.Time) time.Duration {
+	u := t.Unix()
+	for i := len(leapUnix) - 1; i > 0; i-- {
+		if u >= leapUnix[i] {
+			return time.Duration(leapSeconds[i].taiUTC) * time.Second
+		}
+	}
+	return time.Duration(leapSeconds[0].taiUTC) * time.Second
+}
+
+// TAI is ahead of GPS time by the constant 19 s.
+const taiMinusGPST = 19 * time.Second
+
+// offsets of each system time from UTC
+func gpsMinusUTC(t time.Time) time.Duration      { return TAIMinusUTC(t) - taiMinusGPST }
+func bdtMinusUTC(t time.Time) time.Duration      { return gpsMinusUTC(t) - gpstMinusBDT }
+func glonasstMinusUTC(t time.Time) time.Duration { return 3 * time.Hour }
@@ -0,0 +1,26 @@
+package gnss
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logger receives the diagnostics of the package, e.g. how an epoch is
+// parsed. It discards them unless SetLogger is called.
+var logger = slog.New(slog.DiscardHandler)
+
+// SetLogger sets the logger of the diagnostics of the package, which are
+// logged at the debug level. A nil l discards them.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(slog.DiscardHandler)
+	}
+	logger = l
+}
+
+// debugEnabled reports whether the diagnostics are logged. The conversions
+// check it before logging, so that the arguments of discarded diagnostics
+// are not allocated in batch conversions.
+func debugEnabled() bool {
+	return logger.Enabled(context.Background(), slog.LevelDebug)
+}
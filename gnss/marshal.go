@@ -0,0 +1,151 @@
+package gnss
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SatSys) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The text is parsed by ParseSatSys, e.g. "GPS", "G" or "Galileo".
+func (s *SatSys) UnmarshalText(text []byte) error {
+	sys, err := ParseSatSys(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sys
+	return nil
+}
+
+// String returns g in the form of "sys:week:sow", e.g. "GPS:2300:259200".
+func (g GNSSTime) String() string {
+	var buf [32]byte
+	b, _ := g.AppendText(buf[:0])
+	return string(b)
+}
+
+// AppendText implements encoding.TextAppender. It appends the form of
+// String to dst without allocation if dst has enough capacity.
+func (g GNSSTime) AppendText(dst []byte) ([]byte, error) {
+	dst = append(dst, g.Sys...)
+	dst = append(dst, ':')
+	dst = strconv.AppendInt(dst, int64(g.Week), 10)
+	dst = append(dst, ':')
+	return strconv.AppendFloat(dst, g.Sow.Seconds(), 'f', -1, 64), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The text is in the form of "sys:week:sow", e.g. "GPS:2300:259200".
+func (g GNSSTime) MarshalText() ([]byte, error) {
+	return g.AppendText(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (g *GNSSTime) UnmarshalText(text []byte) error {
+	f := strings.SplitN(string(text), ":", 2)
+	if len(f) != 2 {
+		return fmt.Errorf("invalid GNSS time: '%s'", text)
+	}
+
+	var sys SatSys
+	if err := sys.UnmarshalText([]byte(f[0])); err != nil {
+		return err
+	}
+	v, err := ParseWeekDowSow(sys, f[1])
+	if err != nil {
+		return err
+	}
+	*g = v
+	return nil
+}
+
+// gnssTimeJSON is the JSON representation of GNSSTime.
+type gnssTimeJSON struct {
+	Sys  SatSys  `json:"sys"`
+	Week int     `json:"week"`
+	Sow  float64 `json:"sow"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The JSON is an object, e.g. {"sys":"GPS","week":2300,"sow":259200}.
+func (g GNSSTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gnssTimeJSON{Sys: g.Sys, Week: g.Week, Sow: g.Sow.Seconds()})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Both the object and the text form in a string are accepted.
+func (g *GNSSTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return g.UnmarshalText([]byte(s))
+	}
+
+	var v gnssTimeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Sys == "" {
+		v.Sys = SYSGPS
+	}
+	sow := time.Duration(math.Round(v.Sow * 1e9))
+	if v.Week < 0 || v.Sow < 0 || sow >= oneWeek {
+		return fmt.Errorf("invalid GNSS time: %s", data)
+	}
+	*g = GNSSTime{Sys: v.Sys, Week: v.Week, Sow: sow}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The text is in the form of "yyyy:ddd", e.g. "2024:123".
+func (d YearDoy) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *YearDoy) UnmarshalText(text []byte) error {
+	v, err := ParseYearDoy(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// yearDoyJSON is the JSON representation of YearDoy.
+type yearDoyJSON struct {
+	Year int `json:"year"`
+	Doy  int `json:"doy"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The JSON is an object, e.g. {"year":2024,"doy":123}.
+func (d YearDoy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(yearDoyJSON{Year: d.Year, Doy: d.Doy})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Both the object and the text form in a string are accepted.
+func (d *YearDoy) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return d.UnmarshalText([]byte(s))
+	}
+
+	var v yearDoyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if !validDoy(v.Year, v.Doy) {
+		return fmt.Errorf("invalid year:doy: %s", data)
+	}
+	*d = YearDoy{Year: v.Year, Doy: v.Doy}
+	return nil
+}
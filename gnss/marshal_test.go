@@ -0,0 +1,85 @@
+package gnss
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGNSSTimeRoundTrip(t *testing.T) {
+	for _, g := range []GNSSTime{
+		{SYSGPS, 2300, 259200 * time.Second},
+		{SYSGPS, 2300, time.Second + 7*time.Nanosecond},
+		{SYSGAL, 1276, 0},
+		{SYSBDS, 944, oneWeek - time.Nanosecond},
+		{SYSQZS, 0, 123456789 * time.Nanosecond},
+	} {
+		b, err := json.Marshal(g)
+		if err != nil {
+			t.Errorf("json.Marshal(%v): %v", g, err)
+			continue
+		}
+		var got GNSSTime
+		if err := json.Unmarshal(b, &got); err != nil || got != g {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", b, got, err, g)
+		}
+
+		text, err := g.MarshalText()
+		if err != nil {
+			t.Errorf("%v.MarshalText(): %v", g, err)
+			continue
+		}
+		got = GNSSTime{}
+		if err := got.UnmarshalText(text); err != nil || got != g {
+			t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, got, err, g)
+		}
+	}
+}
+
+func TestGNSSTimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want GNSSTime
+	}{
+		{`{"sys":"GPS","week":2300,"sow":1.000000007}`, GNSSTime{SYSGPS, 2300, time.Second + 7*time.Nanosecond}},
+		{`{"week":2300,"sow":259200}`, GNSSTime{SYSGPS, 2300, 259200 * time.Second}},
+		{`"GAL:1276:3:259200"`, GNSSTime{SYSGAL, 1276, 259200 * time.Second}},
+	}
+	for _, tt := range tests {
+		var got GNSSTime
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil || got != tt.want {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", tt.data, got, err, tt.want)
+		}
+	}
+	for _, data := range []string{
+		`{"sys":"GPS","week":-1,"sow":0}`,
+		`{"sys":"GPS","week":2300,"sow":604800}`,
+		`{"sys":"GPS","week":2300,"sow":604799.9999999999}`,
+		`{"sys":"FOO","week":2300,"sow":0}`,
+		`"GPS"`,
+	} {
+		var g GNSSTime
+		if err := json.Unmarshal([]byte(data), &g); err == nil {
+			t.Errorf("json.Unmarshal(%s) = %v, want error", data, g)
+		}
+	}
+}
+
+func TestYearDoyRoundTrip(t *testing.T) {
+	for _, d := range []YearDoy{{2024, 123}, {2024, 366}, {1980, 1}} {
+		b, err := json.Marshal(d)
+		if err != nil {
+			t.Errorf("json.Marshal(%v): %v", d, err)
+			continue
+		}
+		var got YearDoy
+		if err := json.Unmarshal(b, &got); err != nil || got != d {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", b, got, err, d)
+		}
+		text, _ := d.MarshalText()
+		got = YearDoy{}
+		if err := got.UnmarshalText(text); err != nil || got != d {
+			t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, got, err, d)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxFields is the number of fields split without allocation, enough for
+// the epoch records with the epoch flag and the number of satellites.
+const maxFields = 12
+
+// fieldBuf holds the fields split from a string, so that the forms are
+// parsed without allocation when it is on the stack.
+type fieldBuf struct {
+	f  [maxFields]string
+	at [maxFields]int
+}
+
+// split splits s at the separators ':', '-', '/', '_', and white spaces, and
+// returns the fields and their offsets in s.
+func (b *fieldBuf) split(s string) (f []string, at []int) {
+	return appendFields(b.f[:0], b.at[:0], s)
+}
+
+// appendFields appends the fields of s and their offsets to f and at.
+func appendFields(f []string, at []int, s string) ([]string, []int) {
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		sep := i == len(s)
+		if !sep {
+			switch s[i] {
+			case ':', '-', '/', '_', ' ', '\t':
+				sep = true
+			}
+		}
+		switch {
+		case sep && start >= 0:
+			f, at = append(f, s[start:i]), append(at, start)
+			start = -1
+		case !sep && start < 0:
+			start = i
+		}
+	}
+	return f, at
+}
+
+// parseSeconds parses decimal seconds to time.Duration.
+func parseSeconds(s string) (time.Duration, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, fmt.Errorf("invalid seconds: '%s'", s)
+	}
+	return time.Duration(math.Round(f * 1e9)), nil
+}
+
+// ParseYearDoySod parses a time in the form of "yyyy:ddd:sssss" (year, day
+// of year, and seconds of day), e.g. "2024:123:43200", or
+// "yyyy:ddd:hh:mm:ss", e.g. "2024:123:12:00:00", and returns it as time in
+// UTC. The seconds may have a fraction, and the time of day may be omitted.
+// The separator may be ':', '-', '/', '_', or a space.
+func ParseYearDoySod(s string) (time.Time, error) {
+	var buf fieldBuf
+	f, at := buf.split(s)
+	if len(f) != 2 && len(f) != 3 && len(f) != 5 {
+		return time.Time{}, parseError(s, "", -1, "invalid year:doy:sod: '%s'", s)
+	}
+
+	year, err := strconv.Atoi(f[0])
+	if err != nil || len(f[0]) != 4 {
+		return time.Time{}, parseError(s, "", at[0], "invalid year: '%s' in '%s'", f[0], s)
+	}
+	doy, err := strconv.Atoi(f[1])
+	if err != nil || !validDoy(year, doy) {
+		return time.Time{}, parseError(s, "", at[1], "invalid doy: '%s' in '%s'", f[1], s)
+	}
+
+	var sod time.Duration
+	switch len(f) {
+	case 3:
+		if sod, err = parseSeconds(f[2]); err != nil || sod >= oneDay {
+			return time.Time{}, parseError(s, "", at[2], "invalid seconds of day: '%s' in '%s'", f[2], s)
+		}
+	case 5:
+		hour, err := strconv.Atoi(f[2])
+		if err != nil || hour < 0 || 23 < hour {
+			return time.Time{}, parseError(s, "", at[2], "invalid hour: '%s' in '%s'", f[2], s)
+		}
+		min, err := strconv.Atoi(f[3])
+		if err != nil || min < 0 || 59 < min {
+			return time.Time{}, parseError(s, "", at[3], "invalid minute: '%s' in '%s'", f[3], s)
+		}
+		sec, err := parseSeconds(f[4])
+		if err != nil || sec >= time.Minute {
+			return time.Time{}, parseError(s, "", at[4], "invalid second: '%s' in '%s'", f[4], s)
+		}
+		sod = time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + sec
+	}
+
+	return time.Date(year, time.January, doy, 0, 0, 0, 0, time.UTC).Add(sod), nil
+}
+
+// ParseWeekDowSow parses a time of sys in the form of "wwww:d:ssssss" (week,
+// day of week, and seconds of week), e.g. "2300:3:259200". The day of week
+// may be omitted as "wwww:ssssss", and it must be consistent with the seconds
+// of week if given. The separator may be ':', '-', '/', '_', or a space.
+func ParseWeekDowSow(sys SatSys, s string) (GNSSTime, error) {
+	var buf fieldBuf
+	f, at := buf.split(s)
+	if len(f) != 2 && len(f) != 3 {
+		return GNSSTime{}, parseError(s, "", -1, "invalid week:dow:sow: '%s'", s)
+	}
+
+	week, err := strconv.Atoi(f[0])
+	if err != nil || week < 0 {
+		return GNSSTime{}, parseError(s, "", at[0], "invalid week: '%s' in '%s'", f[0], s)
+	}
+	sow, err := parseSeconds(f[len(f)-1])
+	if err != nil || sow >= oneWeek {
+		return GNSSTime{}, parseError(s, "", at[len(f)-1], "invalid seconds of week: '%s' in '%s'", f[len(f)-1], s)
+	}
+	if len(f) == 3 {
+		dow, err := strconv.Atoi(f[1])
+		if err != nil || dow < 0 || 6 < dow {
+			return GNSSTime{}, parseError(s, "", at[1], "invalid day of week: '%s' in '%s'", f[1], s)
+		}
+		if time.Duration(dow)*oneDay > sow || sow >= time.Duration(dow+1)*oneDay {
+			return GNSSTime{}, parseError(s, "", at[1], "day of week %d is inconsistent with seconds of week %s in '%s'", dow, f[2], s)
+		}
+	}
+
+	return GNSSTime{Sys: sys, Week: week, Sow: sow}, nil
+}
+
+// ParseTime parses a time given in one of the following forms and returns
+// it in UTC:
+//
+//	yyyy:ddd[:sod]       year, day of year, and seconds of day, e.g. "2024:123:43200"
+//	yyyy:ddd:hh:mm:ss    year, day of year, and time of day, e.g. "2024:123:12:00:00"
+//	wwww:d:sow           week, day of week, and seconds of week of sys, e.g. "2300:3:259200"
+//	wwww:d               week and day of week of sys, e.g. "2300:3"
+//	wwww:sow             week and seconds of week of sys, e.g. "2300:259200"
+//	yyyy-mm-dd           date, e.g. "2024-05-02"
+//	yyyy-mm-dd hh:mm:ss  date and time of day in UTC, e.g. "2024-05-02 12:00:00.5"
+//	RFC 3339             e.g. "2024-05-02T12:00:00Z"
+//	@seconds             Unix time, e.g. "@1714651200" (see ParseUnix)
+//	MJDnnnnn[.f]         modified Julian date with a fraction, e.g. "MJD60432.5" (see ParseMJD)
+//	epoch record         RINEX 2/3 or SP3 epoch in the time scale of sys, e.g.
+//	                     "> 2024 05 03 12 00 30.0000000" or " 24  5  3 12  0 30.0000000"
+//	                     (see ParseEpochRecord)
+//
+// The forms of two fields are distinguished by the number of digits of the
+// second field; 3 digits for "yyyy:ddd", 1 digit for "wwww:d", and the
+// others for "wwww:sow".
+func ParseTime(sys SatSys, s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if IsEpochRecord(s) {
+		if debugEnabled() {
+			logger.Debug("parsing epoch", "input", s, "form", "epoch record", "satsys", sys)
+		}
+		g, err := ParseEpochRecord(sys, s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return g.Time()
+	}
+
+	if strings.HasPrefix(s, "@") {
+		if debugEnabled() {
+			logger.Debug("parsing epoch", "input", s, "form", "unix")
+		}
+		return ParseUnix(s[1:], time.Second)
+	}
+
+	if len(s) > 3 && strings.EqualFold(s[:3], "MJD") {
+		if debugEnabled() {
+			logger.Debug("parsing epoch", "input", s, "form", "mjd")
+		}
+		return ParseMJD(strings.TrimSpace(s[3:]))
+	}
+
+	// the layouts of time.Parse are tried only for the shapes they can
+	// accept, so that the other forms are parsed without allocation
+	if len(s) == len("2006-01-02") && s[4] == '-' && s[7] == '-' {
+		if t, err := time.Parse("2006-01-02", s); err == nil {
+			if debugEnabled() {
+				logger.Debug("parsing epoch", "input", s, "form", "yyyy-mm-dd")
+			}
+			return t, nil
+		}
+	}
+	if len(s) > len("2006-01-02") && s[10] == ' ' && s[4] == '-' && s[7] == '-' {
+		if t, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
+			if debugEnabled() {
+				logger.Debug("parsing epoch", "input", s, "form", "yyyy-mm-dd hh:mm:ss")
+			}
+			return t, nil
+		}
+	}
+	if len(s) > len("2006-01-02") && (s[10] == 'T' || s[10] == 't') {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			if debugEnabled() {
+				logger.Debug("parsing epoch", "input", s, "form", "RFC 3339")
+			}
+			return t.UTC(), nil
+		}
+	}
+
+	var buf fieldBuf
+	f, at := buf.split(s)
+	if len(f) == 2 && len(f[1]) == 1 {
+		if debugEnabled() {
+			logger.Debug("parsing epoch", "input", s, "form", "wwww:d", "satsys", sys, "reason", "second field has 1 digit")
+		}
+		week, err := strconv.Atoi(f[0])
+		if err != nil || week < 0 {
+			return time.Time{}, parseError(s, "", at[0], "invalid week: '%s' in '%s'", f[0], s)
+		}
+		dow, err := strconv.Atoi(f[1])
+		if err != nil || dow < 0 || 6 < dow {
+			return time.Time{}, parseError(s, "", at[1], "invalid day of week: '%s' in '%s'", f[1], s)
+		}
+		return GNSSTime{Sys: sys, Week: week, Sow: time.Duration(dow) * oneDay}.Time()
+	}
+	if len(f) == 2 && len(f[1]) != 3 {
+		if debugEnabled() {
+			logger.Debug("parsing epoch", "input", s, "form", "wwww:sow", "satsys", sys, "reason", "second field has other than 1 or 3 digits")
+		}
+		g, err := ParseWeekDowSow(sys, s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return g.Time()
+	}
+	if len(f) == 3 && len(f[1]) == 1 {
+		if debugEnabled() {
+			logger.Debug("parsing epoch", "input", s, "form", "wwww:d:sow", "satsys", sys, "reason", "second field has 1 digit")
+		}
+		g, err := ParseWeekDowSow(sys, s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return g.Time()
+	}
+	if len(f) >= 2 && len(f[1]) == 3 {
+		if debugEnabled() {
+			logger.Debug("parsing epoch", "input", s, "form", "yyyy:ddd:sod", "reason", "second field has 3 digits")
+		}
+		return ParseYearDoySod(s)
+	}
+
+	return time.Time{}, parseError(s, "", -1, "unknown time format: '%s'", s)
+}
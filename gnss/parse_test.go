@@ -0,0 +1,98 @@
+package gnss
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	noon := date(2024, 5, 2).Add(12 * time.Hour)
+	// 2300:3:259200 in GPS time is 2024-02-07 00:00:00 GPST, 18 s ahead of UTC
+	week := date(2024, 2, 7).Add(-18 * time.Second)
+	tests := []struct {
+		s    string
+		want time.Time
+	}{
+		{"2024:123", date(2024, 5, 2)},
+		{"2024:123:43200", noon},
+		{"2024:123:43200.5", noon.Add(500 * time.Millisecond)},
+		{"2024:123:12:00:00", noon},
+		{"2024:123:12:00:00.5", noon.Add(500 * time.Millisecond)},
+		{"2024-123-43200", noon},
+		{"2024/123 12:00:00", noon},
+		{"2300:3:259200", week},
+		{"2300:3", week},
+		{"2300:259200", week},
+		{"2300:259200.5", week.Add(500 * time.Millisecond)},
+		{"2300_3_259200", week},
+		{"2024-05-02", date(2024, 5, 2)},
+		{"2024-05-02 12:00:00", noon},
+		{"2024-05-02 12:00:00.5", noon.Add(500 * time.Millisecond)},
+		{"2024-05-02T12:00:00Z", noon},
+		{"2024-05-02T21:00:00+09:00", noon},
+		{"@1714651200", noon},
+		{"@1714651200.5", noon.Add(500 * time.Millisecond)},
+		{"MJD60432.5", noon},
+		{"mjd60432", date(2024, 5, 2)},
+		{"  2024:123:43200  ", noon},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(SYSGPS, tt.s)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %s, %v, want %s", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseTimeError(t *testing.T) {
+	tests := []struct {
+		s      string
+		offset int
+	}{
+		{"2024:367", 5},
+		{"2023:366:0", 5},
+		{"2024:123:86400", 9},
+		{"2024:123:24:00:00", 9},
+		{"2024:123:12:60:00", 12},
+		{"2024:123:12:00:60", 15},
+		{"2300:7", 5},
+		{"2300:7:0", 5},
+		{"2300:3:0", 5},
+		{"2300:604800", 5},
+		{"2300:3:604800", 7},
+		{"x:3", 0},
+		{"abc", -1},
+		{"2024:1234567", 5},
+		{"2024:1:2:3", -1},
+	}
+	for _, tt := range tests {
+		_, err := ParseTime(SYSGPS, tt.s)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseTime(%q): error = %v, want ParseError", tt.s, err)
+			continue
+		}
+		if pe.Offset != tt.offset || pe.Value != tt.s {
+			t.Errorf("ParseTime(%q): error %q at %d of %q, want at %d", tt.s, pe.Msg, pe.Offset, pe.Value, tt.offset)
+		}
+	}
+}
+
+func TestParseWeekDowSow(t *testing.T) {
+	tests := []struct {
+		s    string
+		want GNSSTime
+	}{
+		{"2300:3:259200", GNSSTime{SYSGAL, 2300, 259200 * time.Second}},
+		{"2300:259200", GNSSTime{SYSGAL, 2300, 259200 * time.Second}},
+		{"2300 3 259200.25", GNSSTime{SYSGAL, 2300, 259200*time.Second + 250*time.Millisecond}},
+		{"0:0", GNSSTime{SYSGAL, 0, 0}},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekDowSow(SYSGAL, tt.s)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseWeekDowSow(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package gnss
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DailySession is the RINEX v2 session letter of a daily file.
+const DailySession = '0'
+
+// SessionLetter returns the RINEX v2 hourly session letter of hour,
+// 'a' for 00h through 'x' for 23h.
+func SessionLetter(hour int) (byte, error) {
+	if hour < 0 || 23 < hour {
+		return 0, fmt.Errorf("invalid hour: %d", hour)
+	}
+	return byte('a' + hour), nil
+}
+
+// HourFromSession returns the starting hour of the RINEX v2 session letter.
+// The daily session '0' starts at hour 0.
+func HourFromSession(letter byte) (int, error) {
+	switch {
+	case letter == DailySession:
+		return 0, nil
+	case 'a' <= letter && letter <= 'x':
+		return int(letter - 'a'), nil
+	case 'A' <= letter && letter <= 'X':
+		return int(letter - 'A'), nil
+	}
+	return 0, fmt.Errorf("invalid session letter: '%c'", letter)
+}
+
+// BuildRinex2Name returns the RINEX v2 short filename "ssssdddf.yyt"
+// of station, date, session letter and file type, e.g. "tsk20750.24o".
+//
+// The type is one of the RINEX v2 file types such as 'o' (observation),
+// 'n' (GPS navigation), 'g' (GLONASS navigation), 'm' (meteorological),
+// or 'd' (Hatanaka compressed observation).
+func BuildRinex2Name(station string, date time.Time, session byte, typ byte) (string, error) {
+	if len(station) != 4 {
+		return "", fmt.Errorf("invalid station: '%s', station must be 4 characters", station)
+	}
+	if _, err := HourFromSession(session); err != nil {
+		return "", err
+	}
+	if !strings.ContainsRune(rinex2Types, rune(typ)) {
+		return "", fmt.Errorf("invalid file type: '%c'", typ)
+	}
+
+	return fmt.Sprintf("%s%03d%c.%02d%c", strings.ToLower(station), date.YearDay(), session, date.Year()%100, typ), nil
+}
+
+// rinex2Types are the file types of RINEX v2 short filenames
+const rinex2Types = "onglmdhbcpqfs"
+
+// Rinex3Name holds the fields of a RINEX v3/v4 long filename, e.g.
+// "TSKB00JPN_R_20240750000_01D_30S_MO.crx.gz".
+type Rinex3Name struct {
+	Station     string    // 9 characters; 4-char marker, monument, receiver, and ISO country code, e.g. "TSKB00JPN"
+	Source      byte      // data source; 'R' receiver, 'S' stream, or 'U' unknown
+	Start       time.Time // start time of the file
+	Period      string    // file period, e.g. "01D", "01H", or "15M"
+	Frequency   string    // data frequency, e.g. "30S"; empty for navigation files
+	DataType    string    // e.g. "MO" (mixed observation), "GN" (GPS navigation), or "MM" (meteorological)
+	Format      string    // "rnx" or "crx" (Hatanaka compressed)
+	Compression string    // e.g. "gz"; empty if not compressed
+}
+
+var (
+	rinex3StationPattern   = regexp.MustCompile(`^[A-Z0-9]{9}$`)
+	rinex3PeriodPattern    = regexp.MustCompile(`^[0-9]{2}[MHDYU]$`)
+	rinex3FrequencyPattern = regexp.MustCompile(`^[0-9]{2}[CZSMHDU]$`)
+	rinex3DataTypePattern  = regexp.MustCompile(`^[GREJCISM][ONM]$`)
+)
+
+// Validate reports whether the fields of the filename are valid.
+func (n Rinex3Name) Validate() error {
+	if !rinex3StationPattern.MatchString(n.Station) {
+		return fmt.Errorf("invalid station: '%s', station must be 9 characters, e.g. TSKB00JPN", n.Station)
+	}
+	if !strings.ContainsRune("RSU", rune(n.Source)) || n.Source == 0 {
+		return fmt.Errorf("invalid data source: '%c'", n.Source)
+	}
+	if !rinex3PeriodPattern.MatchString(n.Period) {
+		return fmt.Errorf("invalid period: '%s', period must be like 01D, 01H, or 15M", n.Period)
+	}
+	if !rinex3DataTypePattern.MatchString(n.DataType) {
+		return fmt.Errorf("invalid data type: '%s'", n.DataType)
+	}
+	if n.DataType[1] == 'N' {
+		if n.Frequency != "" {
+			return fmt.Errorf("frequency must be empty for navigation files: '%s'", n.Frequency)
+		}
+	} else if !rinex3FrequencyPattern.MatchString(n.Frequency) {
+		return fmt.Errorf("invalid frequency: '%s', frequency must be like 30S, 01S, or 05Z", n.Frequency)
+	}
+	if n.Format != "rnx" && n.Format != "crx" {
+		return fmt.Errorf("invalid format: '%s', format must be rnx or crx", n.Format)
+	}
+	switch n.Compression {
+	case "", "gz", "bz2", "zip", "Z":
+	default:
+		return fmt.Errorf("invalid compression: '%s'", n.Compression)
+	}
+
+	return nil
+}
+
+// String returns the filename without validation.
+func (n Rinex3Name) String() string {
+	t := n.Start.UTC()
+	name := fmt.Sprintf("%s_%c_%04d%03d%02d%02d_%s", n.Station, n.Source, t.Year(), t.YearDay(), t.Hour(), t.Minute(), n.Period)
+	if n.Frequency != "" {
+		name += "_" + n.Frequency
+	}
+	name += "_" + n.DataType + "." + n.Format
+	if n.Compression != "" {
+		name += "." + n.Compression
+	}
+	return name
+}
+
+// BuildRinex3Name validates n and returns the RINEX v3/v4 long filename.
+func BuildRinex3Name(n Rinex3Name) (string, error) {
+	if err := n.Validate(); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// RinexFileInfo is the information parsed from a RINEX filename.
+type RinexFileInfo struct {
+	Version int       // 2 for short filenames, 3 for long filenames (RINEX v3/v4)
+	Station string    // 4 characters for v2, 9 characters for v3
+	Date    time.Time // start time of the file
+	Session byte      // session letter; 'a'-'x' for hourly, '0' for daily
+	Type    string    // file type of v2, e.g. "o", or data type of v3, e.g. "MO"
+	Week    int       // GPS week; 0 before the epoch of GPS
+	Dow     int       // GPS day of week; 0 before the epoch of GPS
+	Doy     int       // day of year
+}
+
+var (
+	rinex2NamePattern = regexp.MustCompile(`^([A-Za-z0-9]{4})([0-9]{3})([a-xA-X0])\.([0-9]{2})([A-Za-z])(?:\.(?:Z|gz|bz2|zip))?$`)
+	rinex3NamePattern = regexp.MustCompile(`^([A-Z0-9]{9})_([RSU])_([0-9]{4})([0-9]{3})([0-9]{2})([0-9]{2})_([0-9]{2}[MHDYU])(?:_([0-9]{2}[CZSMHDU]))?_([GREJCISM][ONM])\.(?:rnx|crx)(?:\.(?:Z|gz|bz2|zip))?$`)
+)
+
+// ParseRinexName parses a RINEX v2 short filename or v3/v4 long filename
+// of observation, navigation, or meteorological files.
+// The directory part of name is ignored.
+func ParseRinexName(name string) (RinexFileInfo, error) {
+	base := filepath.Base(name)
+	var info RinexFileInfo
+
+	if m := rinex2NamePattern.FindStringSubmatch(base); m != nil {
+		doy, _ := strconv.Atoi(m[2])
+		yy, _ := strconv.Atoi(m[4])
+		year := 2000 + yy
+		if yy >= 80 {
+			year = 1900 + yy
+		}
+
+		if !validDoy(year, doy) {
+			return RinexFileInfo{}, fmt.Errorf("invalid doy: %03d in %s", doy, base)
+		}
+
+		hour, _ := HourFromSession(m[3][0])
+		info = RinexFileInfo{
+			Version: 2,
+			Station: strings.ToLower(m[1]),
+			Date:    time.Date(year, time.January, doy, hour, 0, 0, 0, time.UTC),
+			Session: strings.ToLower(m[3])[0],
+			Type:    strings.ToLower(m[5]),
+		}
+		if !strings.Contains(rinex2Types, info.Type) {
+			return RinexFileInfo{}, fmt.Errorf("invalid file type: '%s' in %s", info.Type, base)
+		}
+	} else if m := rinex3NamePattern.FindStringSubmatch(base); m != nil {
+		year, _ := strconv.Atoi(m[3])
+		doy, _ := strconv.Atoi(m[4])
+		hour, _ := strconv.Atoi(m[5])
+		minute, _ := strconv.Atoi(m[6])
+		if !validDoy(year, doy) {
+			return RinexFileInfo{}, fmt.Errorf("invalid doy: %03d in %s", doy, base)
+		}
+		if hour > 23 || minute > 59 {
+			return RinexFileInfo{}, fmt.Errorf("invalid start time: %s%s in %s", m[5], m[6], base)
+		}
+
+		info = RinexFileInfo{
+			Version: 3,
+			Station: m[1],
+			Date:    time.Date(year, time.January, doy, hour, minute, 0, 0, time.UTC),
+			Session: DailySession,
+			Type:    m[9],
+		}
+		if m[7] == "01H" {
+			info.Session, _ = SessionLetter(hour)
+		}
+	} else {
+		return RinexFileInfo{}, fmt.Errorf("not a RINEX filename: %s", base)
+	}
+
+	// the dates of filenames are in GPS time
+	if g, err := FromSystemTime(SYSGPS, info.Date); err == nil {
+		info.Week = g.Week
+		info.Dow = g.Dow()
+	}
+	info.Doy = info.Date.YearDay()
+
+	return info, nil
+}
+
+// validDoy reports whether doy is a valid day of the year.
+func validDoy(year, doy int) bool {
+	return 1 <= doy && time.Date(year, time.January, doy, 0, 0, 0, 0, time.UTC).Year() == year
+}
+
+// EpochsPerDay returns the number of epochs of a daily RINEX file sampled
+// at interval, e.g. 2880 for 30 seconds. The interval must divide a day.
+func EpochsPerDay(interval time.Duration) (int, error) {
+	if interval <= 0 || oneDay%interval != 0 {
+		return 0, fmt.Errorf("invalid sampling interval: %s, interval must divide a day", interval)
+	}
+	return int(oneDay / interval), nil
+}
+
+// EpochIndex returns the index of the epoch of t within the daily RINEX
+// file of its date, sampled at interval from 00:00, e.g. 1440 for 12:00:00
+// at 30 seconds. An epoch off the sampling grid is given the index of the
+// last epoch before it; compare t with EpochOfIndex of the index to detect it.
+// The date and the time of day are of the location of t, which is
+// usually UTC holding the time scale of the file, e.g. GPS time.
+func EpochIndex(t time.Time, interval time.Duration) (int, error) {
+	if _, err := EpochsPerDay(interval); err != nil {
+		return 0, err
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return int(t.Sub(day) / interval), nil
+}
+
+// EpochOfIndex returns the epoch of index i of the daily RINEX file of date
+// sampled at interval; the inverse of EpochIndex. The index must be less
+// than EpochsPerDay.
+func EpochOfIndex(date time.Time, i int, interval time.Duration) (time.Time, error) {
+	n, err := EpochsPerDay(interval)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if i < 0 || n <= i {
+		return time.Time{}, fmt.Errorf("invalid epoch index: %d, index must be 0-%d at %s", i, n-1, interval)
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return day.Add(time.Duration(i) * interval), nil
+}
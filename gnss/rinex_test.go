@@ -0,0 +1,26 @@
+package gnss
+
+import "testing"
+
+func TestParseRinexNameWeek(t *testing.T) {
+	tests := []struct {
+		name      string
+		week, dow int
+	}{
+		{"abcd0010.80o", 0, 0}, // before the epoch of GPS
+		{"abcd0060.80o", 0, 0},
+		{"abcd0100.80o", 0, 4},
+		{"tskb2340.99o", 1024, 0},
+		{"TSKB00JPN_R_20190970000_01D_30S_MO.crx.gz", 2048, 0},
+	}
+	for _, tt := range tests {
+		info, err := ParseRinexName(tt.name)
+		if err != nil {
+			t.Errorf("ParseRinexName(%s): %v", tt.name, err)
+			continue
+		}
+		if info.Week != tt.week || info.Dow != tt.dow {
+			t.Errorf("ParseRinexName(%s) = week %d dow %d, want week %d dow %d", tt.name, info.Week, info.Dow, tt.week, tt.dow)
+		}
+	}
+}
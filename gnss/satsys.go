@@ -0,0 +1,281 @@
+package gnss
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SatSys is the name of a satellite system, e.g. "GPS".
+type SatSys string
+
+const (
+	SYSGPS SatSys = "GPS"
+	SYSGLO SatSys = "GLO"
+	SYSGAL SatSys = "GAL"
+	SYSQZS SatSys = "QZS"
+	SYSBDS SatSys = "BDS"
+	SYSIRN SatSys = "IRN"
+)
+
+// constants
+// The first day of each satellite system to count week number
+var (
+	GPST0  time.Time = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+	GST0   time.Time = time.Date(1999, time.August, 22, 0, 0, 0, 0, time.UTC)
+	QZSST0 time.Time = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+	BDT0   time.Time = time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)
+	IRNT0  time.Time = time.Date(1999, time.August, 22, 0, 0, 0, 0, time.UTC)
+)
+
+// SystemInfo describes the time reckoning of a satellite system.
+type SystemInfo struct {
+	// Name is the name of the system, e.g. "GPS".
+	Name SatSys
+
+	// Codes are alternative names accepted by Lookup, e.g. the RINEX
+	// single-letter system code.
+	Codes []string
+
+	// Epoch is the first day of the system to count week number.
+	Epoch time.Time
+
+	// EpochFn, if set, overrides Epoch for systems whose week counting
+	// restarts periodically. It returns the epoch applied to t.
+	EpochFn func(t time.Time) time.Time
+
+	// RolloverBits is the bit length of the broadcast week number, e.g.
+	// 10 for the GPS legacy navigation message. 0 means no rollover.
+	RolloverBits int
+
+	// UTCOffsetFn returns the offset of the system time from UTC at t,
+	// i.e. (system time) - UTC.
+	UTCOffsetFn func(t time.Time) time.Duration
+}
+
+// EpochAt returns the epoch of week counting applied to t.
+func (s SystemInfo) EpochAt(t time.Time) time.Time {
+	if s.EpochFn != nil {
+		return s.EpochFn(t)
+	}
+	return s.Epoch
+}
+
+// NextRollover returns the first week number and date at which the broadcast
+// week number rolls over after t. ok is false if the system has no rollover.
+func (s SystemInfo) NextRollover(t time.Time) (week int, date time.Time, ok bool) {
+	if s.RolloverBits <= 0 || s.EpochFn != nil {
+		return 0, time.Time{}, false
+	}
+
+	n := 1 << uint(s.RolloverBits)
+	week = 0
+	if t.After(s.Epoch) {
+		week = WeekNumber(t, s.Epoch)/n*n + n
+	}
+	date = s.Epoch.AddDate(0, 0, 7*week)
+
+	return week, date, true
+}
+
+// UTCOffset returns the offset of the system time from UTC at t.
+// It returns 0 if UTCOffsetFn is not set.
+func (s SystemInfo) UTCOffset(t time.Time) time.Duration {
+	if s.UTCOffsetFn == nil {
+		return 0
+	}
+	return s.UTCOffsetFn(t)
+}
+
+// registry of satellite systems
+var (
+	registryMu sync.RWMutex
+	registry   []SystemInfo
+)
+
+func init() {
+	for _, s := range []SystemInfo{
+		{Name: SYSGPS, Codes: []string{"G"}, Epoch: GPST0, RolloverBits: 10, UTCOffsetFn: gpsMinusUTC},
+		{Name: SYSQZS, Codes: []string{"J"}, Epoch: QZSST0, RolloverBits: 10, UTCOffsetFn: gpsMinusUTC},
+		{Name: SYSGAL, Codes: []string{"E"}, Epoch: GST0, RolloverBits: 12, UTCOffsetFn: gpsMinusUTC},
+		{Name: SYSBDS, Codes: []string{"C"}, Epoch: BDT0, RolloverBits: 13, UTCOffsetFn: bdtMinusUTC},
+		{Name: SYSGLO, Codes: []string{"R"}, EpochFn: LeapYearDate, UTCOffsetFn: glonasstMinusUTC}, // Glonass week starts from the first day of leap year
+		{Name: SYSIRN, Codes: []string{"I"}, Epoch: IRNT0, RolloverBits: 10, UTCOffsetFn: gpsMinusUTC},
+	} {
+		if err := Register(s); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds a satellite system to the registry.
+// It returns an error if the name or one of the codes is already registered.
+func Register(s SystemInfo) error {
+	if s.Name == "" {
+		return fmt.Errorf("empty SatSys name")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, name := range append([]string{string(s.Name)}, s.Codes...) {
+		if _, ok := lookup(name); ok {
+			return fmt.Errorf("SatSys already registered: '%s'", name)
+		}
+	}
+	registry = append(registry, s)
+
+	return nil
+}
+
+// Lookup returns the satellite system registered with the name or code.
+func Lookup(name string) (SystemInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return lookup(name)
+}
+
+func lookup(name string) (SystemInfo, bool) {
+	for _, s := range registry {
+		if string(s.Name) == name {
+			return s, true
+		}
+		for _, code := range s.Codes {
+			if code == name {
+				return s, true
+			}
+		}
+	}
+	return SystemInfo{}, false
+}
+
+// Systems returns the registered satellite systems in order of registration.
+func Systems() []SystemInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return append([]SystemInfo(nil), registry...)
+}
+
+// SystemNames returns the names of registered satellite systems as
+// a comma separated string.
+func SystemNames() string {
+	systems := Systems()
+	names := make([]string, len(systems))
+	for i, s := range systems {
+		names[i] = string(s.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// LeapYearDate returns the first day of the leap year of the four-year
+// interval containing date. Glonass week is counted from this day.
+func LeapYearDate(date time.Time) time.Time {
+	year := date.Year()
+	leapYear := year - year%4
+
+	return time.Date(leapYear, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// satSysAliases maps common names of the built-in systems, in upper case,
+// to the system names.
+var satSysAliases = map[string]SatSys{
+	"GPST":     SYSGPS,
+	"NAVSTAR":  SYSGPS,
+	"GLONASS":  SYSGLO,
+	"GLONASST": SYSGLO,
+	"GLOT":     SYSGLO,
+	"GALILEO":  SYSGAL,
+	"GST":      SYSGAL,
+	"QZSS":     SYSQZS,
+	"QZSST":    SYSQZS,
+	"BEIDOU":   SYSBDS,
+	"COMPASS":  SYSBDS,
+	"BDT":      SYSBDS,
+	"IRNSS":    SYSIRN,
+	"NAVIC":    SYSIRN,
+	"IRNT":     SYSIRN,
+}
+
+// ParseSatSys returns the satellite system given by s.
+// The name or RINEX code of a registered system and the common aliases
+// such as "GLONASS", "Galileo", "BeiDou" and "Compass" are accepted, in any
+// case. For an unknown system the error suggests the closest name, if any.
+func ParseSatSys(s string) (SatSys, error) {
+	name := strings.TrimSpace(s)
+	if info, ok := Lookup(name); ok {
+		return info.Name, nil
+	}
+
+	upper := strings.ToUpper(name)
+	if sys, ok := satSysAliases[upper]; ok {
+		if _, ok := Lookup(string(sys)); ok {
+			logger.Debug("satellite system resolved", "input", s, "satsys", sys, "by", "alias")
+			return sys, nil
+		}
+	}
+
+	sys, suggest := matchSatSys(upper)
+	if sys != "" {
+		logger.Debug("satellite system resolved", "input", s, "satsys", sys, "by", "case-insensitive match")
+		return sys, nil
+	}
+	if suggest != "" && len(upper) > 1 {
+		return "", fmt.Errorf("%w: '%s'. did you mean %s? valid systems: %s", ErrUnknownSatSys, s, suggest, SystemNames())
+	}
+	return "", fmt.Errorf("%w: '%s'. valid systems: %s", ErrUnknownSatSys, s, SystemNames())
+}
+
+// matchSatSys returns the system whose name or code matches upper in any
+// case, or the closest name within the edit distance of 2 as suggest.
+func matchSatSys(upper string) (sys, suggest SatSys) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	best := 3
+	for _, info := range registry {
+		if strings.ToUpper(string(info.Name)) == upper {
+			return info.Name, ""
+		}
+		for _, code := range info.Codes {
+			if strings.ToUpper(code) == upper {
+				return info.Name, ""
+			}
+		}
+		if d := editDistance(upper, strings.ToUpper(string(info.Name))); d < best {
+			best, suggest = d, info.Name
+		}
+	}
+	for alias, name := range satSysAliases {
+		if _, ok := lookup(string(name)); !ok {
+			continue
+		}
+		if d := editDistance(upper, alias); d < best || (d == best && name < suggest) {
+			best, suggest = d, name
+		}
+	}
+	return "", suggest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,98 @@
+package gnss
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSatSysUnknown(t *testing.T) {
+	valid := "valid systems: " + SystemNames()
+	tests := []struct {
+		s, want string
+	}{
+		{"FOO", "unknown SatSys: 'FOO'. did you mean GLO? " + valid},
+		{"XYZW", "unknown SatSys: 'XYZW'. " + valid},
+		{"X", "unknown SatSys: 'X'. " + valid},
+	}
+	for _, tt := range tests {
+		_, err := ParseSatSys(tt.s)
+		if !errors.Is(err, ErrUnknownSatSys) {
+			t.Errorf("ParseSatSys(%q): error = %v, want ErrUnknownSatSys", tt.s, err)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("ParseSatSys(%q): error = %q, want %q", tt.s, err, tt.want)
+		}
+	}
+}
+
+func TestParseSatSys(t *testing.T) {
+	tests := []struct {
+		s    string
+		want SatSys
+	}{
+		{"GPS", SYSGPS},
+		{"gps", SYSGPS},
+		{" Gal ", SYSGAL},
+		{"G", SYSGPS},
+		{"r", SYSGLO},
+		{"E", SYSGAL},
+		{"c", SYSBDS},
+		{"J", SYSQZS},
+		{"I", SYSIRN},
+		{"Galileo", SYSGAL},
+		{"BeiDou", SYSBDS},
+		{"compass", SYSBDS},
+		{"GLONASS", SYSGLO},
+		{"Navstar", SYSGPS},
+		{"QZSS", SYSQZS},
+		{"NavIC", SYSIRN},
+		{"bdt", SYSBDS},
+	}
+	for _, tt := range tests {
+		if got, err := ParseSatSys(tt.s); err != nil || got != tt.want {
+			t.Errorf("ParseSatSys(%q) = %s, %v, want %s", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseSatSysSuggestion(t *testing.T) {
+	tests := []struct {
+		s    string
+		want SatSys
+	}{
+		{"GPX", SYSGPS},
+		{"GSP", SYSGAL}, // GST is closer than GPS
+		{"GLONAS", SYSGLO},
+		{"Galilio", SYSGAL},
+		{"Beidoo", SYSBDS},
+		{"QZZS", SYSQZS},
+	}
+	for _, tt := range tests {
+		_, err := ParseSatSys(tt.s)
+		want := "did you mean " + string(tt.want) + "?"
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseSatSys(%q): error = %v, want %q", tt.s, err, want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"GPS", "GPS", 0},
+		{"GPS", "", 3},
+		{"GSP", "GPS", 2},
+		{"GALILIO", "GALILEO", 1},
+		{"KITTEN", "SITTING", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package gnss
+
+import (
+	"iter"
+	"time"
+)
+
+// Rule selects the days of a schedule, e.g. of periodic reprocessing jobs.
+type Rule func(d Day) bool
+
+// EveryNthDay returns the rule of every n-th day counted from the date of
+// anchor, which is selected itself; days before anchor are also selected
+// every n days. It selects no day if n < 1.
+func EveryNthDay(n int, anchor time.Time) Rule {
+	first := utcDate(anchor)
+	return func(d Day) bool {
+		return n >= 1 && floorMod(DaysBetween(first, d.Date), n) == 0
+	}
+}
+
+// WeekDay returns the rule of the days of GNSS week whose day of week is
+// dow (0 for Sunday), e.g. the days of weekly products. Days before the
+// epoch of the system are not selected.
+func WeekDay(dow int) Rule {
+	return func(d Day) bool {
+		return d.Week >= 0 && d.Dow == dow
+	}
+}
+
+// MonthDay returns the rule of the day of month day, e.g. 1 for the first
+// day of each month. A negative day counts from the end of the month, -1
+// for the last day. Months without the day, e.g. 31 of April, are skipped.
+func MonthDay(day int) Rule {
+	return func(d Day) bool {
+		want := day
+		if want < 0 {
+			y, m, _ := d.Date.Date()
+			want += time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day() + 1
+		}
+		return d.Date.Day() == want
+	}
+}
+
+// Schedule returns an iterator over the days of r selected by rule,
+// annotated with the weeks of sys as Week.Days does.
+//
+//	r := gnss.DateRange{Start: from, End: to}
+//	for d := range gnss.Schedule(gnss.SYSGPS, r, gnss.WeekDay(0)) {
+//		fmt.Println(d.Date.Format("2006-01-02"), d.Doy, d.Week)
+//	}
+func Schedule(sys SatSys, r DateRange, rule Rule) iter.Seq[Day] {
+	return func(yield func(Day) bool) {
+		for d := range systemDays(sys, r.Start, r.End) {
+			if rule(d) && !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// floorMod returns a modulo b in [0, b) for b > 0.
+func floorMod(a, b int) int {
+	return (a%b + b) % b
+}
@@ -0,0 +1,191 @@
+// Package spaceweather reads daily indices of geomagnetic and solar
+// activity, the planetary Kp and Ap indices and the F10.7 solar radio
+// flux, which govern the disturbance of the ionosphere, e.g. to choose
+// processing windows.
+//
+// Like iers, the package is optional and not imported by the gnss package.
+// Historical indices are read from the Kp_ap_Ap_SN_F107 files of GFZ
+// Potsdam, and forecasts from the 27-day outlook of NOAA SWPC.
+package spaceweather
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Index is the indices of a day.
+type Index struct {
+	Date      time.Time // 00:00 UTC
+	Kp        float64   // largest 3-hourly Kp of the day, e.g. 5.333 for 5+
+	Ap        int       // daily Ap
+	F107      float64   // observed F10.7 in solar flux units, or 0 if unknown
+	Predicted bool      // true for forecasts
+}
+
+// Indices is a table of daily indices.
+type Indices struct {
+	Entries []Index // sorted by date
+}
+
+// logger receives the diagnostics of the package. It discards them unless
+// SetLogger is called.
+var logger = slog.New(slog.DiscardHandler)
+
+// SetLogger sets the logger of the diagnostics of the package, which are
+// logged at the debug level. A nil l discards them.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(slog.DiscardHandler)
+	}
+	logger = l
+}
+
+// HistoryURL is the file of the indices since 1932 of GFZ Potsdam, updated
+// daily; NowcastURL is of the recent days only.
+const (
+	HistoryURL = "https://kp.gfz-potsdam.de/app/files/Kp_ap_Ap_SN_F107_since_1932.txt"
+	NowcastURL = "https://kp.gfz-potsdam.de/app/files/Kp_ap_Ap_SN_F107_nowcast.txt"
+)
+
+// OutlookURL is the 27-day outlook of F10.7 and geomagnetic indices of
+// NOAA SWPC, updated weekly.
+const OutlookURL = "https://services.swpc.noaa.gov/text/27-day-outlook.txt"
+
+// LoadGFZ reads a Kp_ap_Ap_SN_F107 file of GFZ Potsdam from r. The lines
+// are of the form
+//
+//	YYYY MM DD days days_m Bsr dB Kp1 ... Kp8 ap1 ... ap8 Ap SN F10.7obs F10.7adj D
+//
+// Lines starting with '#' are comments, and values of -1 are missing; the
+// Kp of a day without any is -1.
+func LoadGFZ(r io.Reader) (*Indices, error) {
+	ind := &Indices{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) < 28 {
+			return nil, fmt.Errorf("invalid line %d: '%s'", n, line)
+		}
+		date, err := parseDate(f[0], f[1], f[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date at line %d: %v", n, err)
+		}
+
+		e := Index{Date: date, Kp: -1}
+		for _, s := range f[7:15] {
+			kp, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Kp at line %d: '%s'", n, s)
+			}
+			e.Kp = max(e.Kp, kp)
+		}
+		if e.Ap, err = strconv.Atoi(f[23]); err != nil {
+			return nil, fmt.Errorf("invalid Ap at line %d: '%s'", n, f[23])
+		}
+		if e.F107, err = strconv.ParseFloat(f[25], 64); err != nil {
+			return nil, fmt.Errorf("invalid F10.7 at line %d: '%s'", n, f[25])
+		}
+		e.F107 = max(e.F107, 0)
+		ind.Entries = append(ind.Entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return ind.sorted("gfz")
+}
+
+// LoadOutlook reads the 27-day outlook of NOAA SWPC from r, of which the
+// lines of data are of the form
+//
+//	2024 May 06     150          12          4
+//
+// giving the F10.7, the Ap, and the largest Kp forecasted for a day.
+// Lines starting with ':' or '#' are comments.
+func LoadOutlook(r io.Reader) (*Indices, error) {
+	ind := &Indices{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if strings.HasPrefix(line, ":") || strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) != 6 {
+			return nil, fmt.Errorf("invalid line %d: '%s'", n, line)
+		}
+		date, err := time.Parse("2006 Jan 02", strings.Join(f[:3], " "))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date at line %d: %v", n, err)
+		}
+		f107, err1 := strconv.ParseFloat(f[3], 64)
+		ap, err2 := strconv.Atoi(f[4])
+		kp, err3 := strconv.ParseFloat(f[5], 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("invalid indices at line %d: '%s'", n, line)
+		}
+		ind.Entries = append(ind.Entries, Index{Date: date, Kp: kp, Ap: ap, F107: f107, Predicted: true})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return ind.sorted("outlook")
+}
+
+// sorted sorts the entries of ind by date, or returns an error if none.
+func (ind *Indices) sorted(source string) (*Indices, error) {
+	if len(ind.Entries) == 0 {
+		return nil, fmt.Errorf("no indices found")
+	}
+	sort.SliceStable(ind.Entries, func(i, j int) bool { return ind.Entries[i].Date.Before(ind.Entries[j].Date) })
+	logger.Debug("space weather indices loaded", "source", source, "entries", len(ind.Entries),
+		"first", ind.Entries[0].Date.Format("2006-01-02"), "last", ind.Entries[len(ind.Entries)-1].Date.Format("2006-01-02"))
+	return ind, nil
+}
+
+// parseDate parses the year, month and day fields of a line.
+func parseDate(year, month, day string) (time.Time, error) {
+	return time.Parse("2006 1 2", year+" "+month+" "+day)
+}
+
+// Merge returns the indices of ind completed by the days of o missing in
+// ind, e.g. historical indices followed by forecasts; an entry of ind
+// without Kp is replaced by that of o.
+func (ind *Indices) Merge(o *Indices) *Indices {
+	byDate := make(map[time.Time]Index, len(ind.Entries)+len(o.Entries))
+	for _, e := range o.Entries {
+		byDate[e.Date] = e
+	}
+	for _, e := range ind.Entries {
+		if e.Kp >= 0 || byDate[e.Date].Date.IsZero() {
+			byDate[e.Date] = e
+		}
+	}
+
+	m := &Indices{Entries: make([]Index, 0, len(byDate))}
+	for _, e := range byDate {
+		m.Entries = append(m.Entries, e)
+	}
+	sort.Slice(m.Entries, func(i, j int) bool { return m.Entries[i].Date.Before(m.Entries[j].Date) })
+	return m
+}
+
+// At returns the indices of the date of t in UTC, or false if not in the
+// table.
+func (ind *Indices) At(t time.Time) (Index, bool) {
+	date := t.UTC().Truncate(24 * time.Hour)
+	i := sort.Search(len(ind.Entries), func(i int) bool { return !ind.Entries[i].Date.Before(date) })
+	if i == len(ind.Entries) || !ind.Entries[i].Date.Equal(date) {
+		return Index{}, false
+	}
+	return ind.Entries[i], true
+}
@@ -0,0 +1,70 @@
+package gnss
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Value implements driver.Valuer.
+// GNSSTime is stored in the text form, e.g. "GPS:2300:259200", so that the
+// satellite system is kept along with the week and the seconds of week.
+func (g GNSSTime) Value() (driver.Value, error) {
+	return g.String(), nil
+}
+
+// Scan implements sql.Scanner.
+// The text form (string or []byte) and timestamps are accepted.
+// Timestamps are converted to GPS time.
+func (g *GNSSTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return g.UnmarshalText([]byte(v))
+	case []byte:
+		return g.UnmarshalText(v)
+	case time.Time:
+		t, err := TimeOf(SYSGPS, v)
+		if err != nil {
+			return err
+		}
+		*g = t
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into GNSSTime", src)
+}
+
+// Value implements driver.Valuer.
+// YearDoy is stored as a timestamp at 00:00 UTC, which maps to a date column.
+func (d YearDoy) Value() (driver.Value, error) {
+	if !d.Valid() {
+		return nil, fmt.Errorf("invalid year:doy: %s", d)
+	}
+	return d.Time(), nil
+}
+
+// Scan implements sql.Scanner.
+// Dates, timestamps and the text form (string or []byte) are accepted.
+func (d *YearDoy) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = YearDoyOf(v)
+		return nil
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	}
+	return fmt.Errorf("cannot scan %T into YearDoy", src)
+}
+
+// scanString parses s as a date ("yyyy-mm-dd", as returned by most drivers
+// for a date column in text mode) or in the form of "yyyy:ddd".
+func (d *YearDoy) scanString(s string) error {
+	if len(s) >= 10 {
+		if t, err := time.Parse("2006-01-02", s[:10]); err == nil {
+			*d = YearDoyOf(t)
+			return nil
+		}
+	}
+	return d.UnmarshalText([]byte(s))
+}
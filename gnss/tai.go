@@ -0,0 +1,38 @@
+package gnss
+
+import "time"
+
+// UTCToTAI returns the calendar time in TAI of t (UTC).
+// TAI is ahead of UTC by the accumulated leap seconds, and ahead of GPS time
+// by 19 s.
+func UTCToTAI(t time.Time) time.Time {
+	t = t.UTC()
+	return t.Add(TAIMinusUTC(t))
+}
+
+// TAIToUTC returns the UTC time of tai, which is a calendar time in TAI.
+// The location of tai is ignored.
+func TAIToUTC(tai time.Time) time.Time {
+	tai = time.Date(tai.Year(), tai.Month(), tai.Day(), tai.Hour(), tai.Minute(), tai.Second(), tai.Nanosecond(), time.UTC)
+	t := tai.Add(-TAIMinusUTC(tai))
+	return tai.Add(-TAIMinusUTC(t))
+}
+
+// Elapsed returns the physical (SI) time elapsed from t1 to t2 (UTC),
+// including the leap seconds inserted between them. It equals the
+// difference in TAI and in GPS time, while t2.Sub(t1) does not count the
+// leap seconds.
+func Elapsed(t1, t2 time.Time) time.Duration {
+	return UTCToTAI(t2).Sub(UTCToTAI(t1))
+}
+
+// UTCToGPST returns the calendar time in GPS time of t (UTC).
+func UTCToGPST(t time.Time) time.Time {
+	return UTCToTAI(t).Add(-taiMinusGPST)
+}
+
+// GPSTToUTC returns the UTC time of gpst, which is a calendar time in GPS time.
+// The location of gpst is ignored.
+func GPSTToUTC(gpst time.Time) time.Time {
+	return TAIToUTC(gpst.Add(taiMinusGPST))
+}
@@ -0,0 +1,161 @@
+package gnss
+
+import (
+	"fmt"
+	"time"
+)
+
+// durations
+const (
+	oneDay  time.Duration = time.Hour * 24
+	oneWeek time.Duration = oneDay * 7
+)
+
+// Moscow is the zone of Moscow time (UTC+3), in which GLONASS time and the
+// day boundaries of GLONASS ephemerides are defined.
+var Moscow = time.FixedZone("MSK", 3*60*60)
+
+// MoscowDate returns the date of t in Moscow time as a date at 00:00 UTC,
+// i.e. the GLONASS day containing t, which starts at 21:00 UTC of the
+// day before.
+func MoscowDate(t time.Time) time.Time {
+	t = t.In(Moscow)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DaysBetween returns the number of whole days from from to to, negative
+// if to is before from. The days are counted in integer seconds so that
+// it is exact for any dates, whereas time.Duration overflows beyond 292
+// years.
+func DaysBetween(from, to time.Time) int {
+	return floorDiv(int(to.Unix()-from.Unix()), 86400)
+}
+
+// WeekNumber returns the week of date counted from epoch, negative before
+// the epoch.
+func WeekNumber(date, epoch time.Time) int {
+	return floorDiv(DaysBetween(epoch, date), 7)
+}
+
+// DayOfWeek returns the day of the week of date counted from epoch, 0 for
+// the day of the week of epoch.
+func DayOfWeek(date, epoch time.Time) int {
+	return floorMod(DaysBetween(epoch, date), 7)
+}
+
+// floorDiv returns a/b rounded toward negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// GNSSTime is a time expressed in the week number and the time of week
+// of a satellite system.
+type GNSSTime struct {
+	Sys  SatSys
+	Week int           // week number counted from the epoch of Sys
+	Sow  time.Duration // elapsed time since the beginning of the week
+}
+
+// TimeOf converts t to the system time of sys and returns it as GNSSTime.
+func TimeOf(sys SatSys, t time.Time) (GNSSTime, error) {
+	s, ok := Lookup(string(sys))
+	if !ok {
+		return GNSSTime{}, unknownSatSys(sys)
+	}
+
+	return fromSystemTime(s, t.UTC().Add(s.UTCOffset(t)))
+}
+
+// FromSystemTime returns GNSSTime of st, which is a calendar time in the
+// time scale of sys (e.g. an epoch of RINEX observation files in GPS time).
+// The location of st is ignored.
+func FromSystemTime(sys SatSys, st time.Time) (GNSSTime, error) {
+	s, ok := Lookup(string(sys))
+	if !ok {
+		return GNSSTime{}, unknownSatSys(sys)
+	}
+	return fromSystemTime(s, st)
+}
+
+// fromSystemTime is FromSystemTime with the system looked up.
+func fromSystemTime(s SystemInfo, st time.Time) (GNSSTime, error) {
+	if st.Location() != time.UTC {
+		st = time.Date(st.Year(), st.Month(), st.Day(), st.Hour(), st.Minute(), st.Second(), st.Nanosecond(), time.UTC)
+	}
+	epoch := s.EpochAt(st)
+	if st.Before(epoch) {
+		return GNSSTime{}, beforeEpoch("%s is before the epoch of %s", st.Format("2006-01-02"), s.Name)
+	}
+
+	n := DaysBetween(epoch, st)
+	return GNSSTime{
+		Sys:  s.Name,
+		Week: n / 7,
+		Sow:  time.Duration(n%7)*oneDay + st.Sub(epoch.AddDate(0, 0, n)),
+	}, nil
+}
+
+// Time returns the UTC time of g.
+// It returns an error for systems whose week counting restarts periodically
+// (GLONASS), since the week number is ambiguous.
+func (g GNSSTime) Time() (time.Time, error) {
+	s, ok := Lookup(string(g.Sys))
+	if !ok {
+		return time.Time{}, unknownSatSys(g.Sys)
+	}
+	if s.EpochFn != nil {
+		return time.Time{}, fmt.Errorf("week of %s is ambiguous", s.Name)
+	}
+
+	st := s.Epoch.AddDate(0, 0, 7*g.Week).Add(g.Sow)
+	t := st.Add(-s.UTCOffset(st))
+
+	return st.Add(-s.UTCOffset(t)), nil
+}
+
+// Dow returns the day of week, 0 for Sunday.
+func (g GNSSTime) Dow() int {
+	return int(g.Sow / oneDay)
+}
+
+// Seconds returns the seconds of week.
+func (g GNSSTime) Seconds() float64 {
+	return g.Sow.Seconds()
+}
+
+// Progress returns the percentage of the week elapsed.
+func (g GNSSTime) Progress() float64 {
+	return 100 * g.Sow.Seconds() / oneWeek.Seconds()
+}
+
+// ConvertWeek returns the week number of toSys that starts on the same date
+// as week of fromSys, e.g. GPS week 2300 is GAL week 1276 and BDS week 944.
+// The offsets of the system times (e.g. 14 s of BDT) are not taken into
+// account; weeks are related by the dates.
+// It returns an error for systems whose week counting restarts periodically
+// (GLONASS), or if the week is before the epoch of toSys.
+func ConvertWeek(week int, fromSys, toSys SatSys) (int, error) {
+	from, ok := Lookup(string(fromSys))
+	if !ok {
+		return 0, unknownSatSys(fromSys)
+	}
+	to, ok := Lookup(string(toSys))
+	if !ok {
+		return 0, unknownSatSys(toSys)
+	}
+	for _, s := range []SystemInfo{from, to} {
+		if s.EpochFn != nil {
+			return 0, fmt.Errorf("week of %s is ambiguous", s.Name)
+		}
+	}
+
+	date := from.Epoch.AddDate(0, 0, 7*week)
+	if date.Before(to.Epoch) {
+		return 0, beforeEpoch("%s week %d is before the epoch of %s", from.Name, week, to.Name)
+	}
+	return WeekNumber(date, to.Epoch), nil
+}
@@ -0,0 +1,156 @@
+package gnss
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWeekNumber(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      time.Time
+		epoch     time.Time
+		week, dow int
+	}{
+		{"GPS epoch", date(1980, 1, 6), GPST0, 0, 0},
+		{"day before GPS epoch", date(1980, 1, 5), GPST0, -1, 6},
+		{"GAL epoch", date(1999, 8, 22), GST0, 0, 0},
+		{"BDS epoch", date(2006, 1, 1), BDT0, 0, 0},
+		{"GAL week", date(2024, 5, 2), GST0, 1288, 4},
+		{"BDS week", date(2024, 5, 2), BDT0, 956, 4},
+		{"before 1999 rollover", date(1999, 8, 21), GPST0, 1023, 6},
+		{"1999 rollover", date(1999, 8, 22), GPST0, 1024, 0},
+		{"before 2019 rollover", date(2019, 4, 6), GPST0, 2047, 6},
+		{"2019 rollover", date(2019, 4, 7), GPST0, 2048, 0},
+		{"Dec 31 of common year", date(2023, 12, 31), GPST0, 2295, 0},
+		{"Jan 1 after common year", date(2024, 1, 1), GPST0, 2295, 1},
+		{"Dec 31 of leap year", date(2024, 12, 31), GPST0, 2347, 2},
+		{"Jan 1 after leap year", date(2025, 1, 1), GPST0, 2347, 3},
+		{"Dec 31 of 2100", date(2100, 12, 31), GPST0, 6312, 5},
+		{"Jan 1 of 2101", date(2101, 1, 1), GPST0, 6312, 6},
+		{"beyond time.Duration", date(9999, 12, 31), GPST0, 418462, 5},
+	}
+	for _, tt := range tests {
+		if got := WeekNumber(tt.date, tt.epoch); got != tt.week {
+			t.Errorf("%s: WeekNumber(%s) = %d, want %d", tt.name, tt.date.Format("2006-01-02"), got, tt.week)
+		}
+		if got := DayOfWeek(tt.date, tt.epoch); got != tt.dow {
+			t.Errorf("%s: DayOfWeek(%s) = %d, want %d", tt.name, tt.date.Format("2006-01-02"), got, tt.dow)
+		}
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	tests := []struct {
+		from, to time.Time
+		want     int
+	}{
+		{date(2024, 12, 31), date(2025, 1, 1), 1},
+		{date(2024, 1, 1), date(2025, 1, 1), 366},
+		{date(2023, 1, 1), date(2024, 1, 1), 365},
+		{date(2100, 1, 1), date(2101, 1, 1), 365},
+		{date(2000, 1, 1), date(2001, 1, 1), 366},
+		{date(1980, 1, 6), date(1980, 1, 5), -1},
+		{date(1980, 1, 6), date(1980, 1, 5).Add(time.Hour), -1},
+		{date(1980, 1, 6), date(1980, 1, 6).Add(23 * time.Hour), 0},
+	}
+	for _, tt := range tests {
+		if got := DaysBetween(tt.from, tt.to); got != tt.want {
+			t.Errorf("DaysBetween(%s, %s) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestFromSystemTime(t *testing.T) {
+	tests := []struct {
+		sys  SatSys
+		st   time.Time
+		week int
+		sow  time.Duration
+	}{
+		{SYSGPS, GPST0, 0, 0},
+		{SYSGAL, GST0, 0, 0},
+		{SYSBDS, BDT0, 0, 0},
+		{SYSGPS, date(1999, 8, 21).Add(24*time.Hour - time.Nanosecond), 1023, 7*24*time.Hour - time.Nanosecond},
+		{SYSGPS, date(1999, 8, 22), 1024, 0},
+		{SYSGPS, date(2019, 4, 7).Add(90 * time.Minute), 2048, 90 * time.Minute},
+		{SYSGPS, date(2024, 12, 31).Add(12 * time.Hour), 2347, 2*24*time.Hour + 12*time.Hour},
+		{SYSGPS, date(2025, 1, 1), 2347, 3 * 24 * time.Hour},
+		{SYSGPS, date(9999, 12, 31), 418462, 5 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		g, err := FromSystemTime(tt.sys, tt.st)
+		if err != nil {
+			t.Errorf("FromSystemTime(%s, %s): %v", tt.sys, tt.st, err)
+			continue
+		}
+		if g.Week != tt.week || g.Sow != tt.sow {
+			t.Errorf("FromSystemTime(%s, %s) = week %d sow %v, want week %d sow %v", tt.sys, tt.st, g.Week, g.Sow, tt.week, tt.sow)
+		}
+	}
+
+	for _, tt := range []struct {
+		sys SatSys
+		st  time.Time
+	}{
+		{SYSGPS, GPST0.Add(-time.Nanosecond)},
+		{SYSGAL, GST0.Add(-time.Nanosecond)},
+		{SYSBDS, BDT0.Add(-time.Nanosecond)},
+	} {
+		if _, err := FromSystemTime(tt.sys, tt.st); !errors.Is(err, ErrBeforeSystemEpoch) {
+			t.Errorf("FromSystemTime(%s, %s): error = %v, want ErrBeforeSystemEpoch", tt.sys, tt.st, err)
+		}
+	}
+}
+
+func TestConvertWeek(t *testing.T) {
+	tests := []struct {
+		week     int
+		from, to SatSys
+		want     int
+	}{
+		{2300, SYSGPS, SYSGAL, 1276},
+		{2300, SYSGPS, SYSBDS, 944},
+		{1276, SYSGAL, SYSGPS, 2300},
+		{20000, SYSGPS, SYSGAL, 18976},
+	}
+	for _, tt := range tests {
+		got, err := ConvertWeek(tt.week, tt.from, tt.to)
+		if err != nil || got != tt.want {
+			t.Errorf("ConvertWeek(%d, %s, %s) = %d, %v, want %d", tt.week, tt.from, tt.to, got, err, tt.want)
+		}
+	}
+	if _, err := ConvertWeek(1023, SYSGPS, SYSGAL); !errors.Is(err, ErrBeforeSystemEpoch) {
+		t.Errorf("ConvertWeek(1023, GPS, GAL): error = %v, want ErrBeforeSystemEpoch", err)
+	}
+}
+
+func TestGNSSTimeTime(t *testing.T) {
+	for _, utc := range []time.Time{
+		GPST0,
+		date(1999, 8, 22),
+		date(2019, 4, 7),
+		date(2024, 12, 31).Add(23*time.Hour + 59*time.Minute),
+		date(2025, 1, 1),
+		date(2400, 2, 29),
+	} {
+		for _, sys := range []SatSys{SYSGPS, SYSGAL, SYSBDS} {
+			g, err := TimeOf(sys, utc)
+			if errors.Is(err, ErrBeforeSystemEpoch) {
+				continue
+			} else if err != nil {
+				t.Errorf("TimeOf(%s, %s): %v", sys, utc, err)
+				continue
+			}
+			got, err := g.Time()
+			if err != nil {
+				t.Errorf("%v.Time(): %v", g, err)
+				continue
+			}
+			if !got.Equal(utc) {
+				t.Errorf("%v.Time() = %s, want %s", g, got, utc)
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package gnss
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnixUnits maps the names of units of Unix time to the durations.
+var UnixUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// ParseUnix parses s as Unix time, the elapsed time since 1970-01-01 UTC
+// without leap seconds, counted in unit (time.Second, time.Millisecond,
+// time.Microsecond or time.Nanosecond). A fraction is allowed for seconds,
+// e.g. "1714651200.5".
+func ParseUnix(s string, unit time.Duration) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if unit == time.Second {
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			sec, err := strconv.ParseInt(s[:i], 10, 64)
+			if err != nil {
+				return time.Time{}, parseError(s, "", -1, "invalid Unix time: '%s'", s)
+			}
+			frac := (s[i+1:] + "000000000")[:9]
+			nsec, err := strconv.ParseInt(frac, 10, 64)
+			if err != nil || len(s) == i+1 {
+				return time.Time{}, parseError(s, "", -1, "invalid Unix time: '%s'", s)
+			}
+			if strings.HasPrefix(s, "-") {
+				nsec = -nsec
+			}
+			return time.Unix(sec, nsec).UTC(), nil
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, parseError(s, "", -1, "invalid Unix time: '%s'", s)
+	}
+	switch unit {
+	case time.Second:
+		return time.Unix(n, 0).UTC(), nil
+	case time.Millisecond, time.Microsecond, time.Nanosecond:
+		per := int64(time.Second / unit)
+		return time.Unix(n/per, n%per*int64(unit)).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid unit of Unix time: %v", unit)
+}
+
+// FormatUnix returns Unix time of t counted in unit. The seconds are
+// formatted with the fraction if any, e.g. "1714651200.5".
+func FormatUnix(t time.Time, unit time.Duration) string {
+	if unit == time.Second {
+		sec, nsec := t.Unix(), t.Nanosecond()
+		if nsec == 0 {
+			return strconv.FormatInt(sec, 10)
+		}
+		// t.Unix() is floored; the fraction of negative times is of the
+		// magnitude, e.g. "-1.5" for -2 s + 0.5 s
+		sign := ""
+		if sec < 0 {
+			sign, sec, nsec = "-", -(sec + 1), int(time.Second)-nsec
+		}
+		return sign + strconv.FormatInt(sec, 10) + strings.TrimRight(fmt.Sprintf(".%09d", nsec), "0")
+	}
+	return strconv.FormatInt(t.Unix()*int64(time.Second/unit)+int64(t.Nanosecond())/int64(unit), 10)
+}
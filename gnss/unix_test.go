@@ -0,0 +1,43 @@
+package gnss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnix(t *testing.T) {
+	tests := []struct {
+		s    string
+		unit time.Duration
+		want time.Time
+	}{
+		{"1714651200", time.Second, time.Date(2024, time.May, 2, 12, 0, 0, 0, time.UTC)},
+		{"1714651200.5", time.Second, time.Date(2024, time.May, 2, 12, 0, 0, 5e8, time.UTC)},
+		{"0", time.Second, time.Unix(0, 0).UTC()},
+		{"0.000000001", time.Second, time.Unix(0, 1).UTC()},
+		{"-1", time.Second, time.Unix(-1, 0).UTC()},
+		{"-0.5", time.Second, time.Unix(0, -5e8).UTC()},
+		{"-1.5", time.Second, time.Unix(-1, -5e8).UTC()},
+		{"-315964800.25", time.Second, time.Date(1959, time.December, 27, 23, 59, 59, 75e7, time.UTC)},
+		{"1714651200500", time.Millisecond, time.Date(2024, time.May, 2, 12, 0, 0, 5e8, time.UTC)},
+		{"-1500", time.Millisecond, time.Unix(-1, -5e8).UTC()},
+		{"-1500000", time.Microsecond, time.Unix(-1, -5e8).UTC()},
+		{"-1500000000", time.Nanosecond, time.Unix(-1, -5e8).UTC()},
+	}
+	for _, tt := range tests {
+		got, err := ParseUnix(tt.s, tt.unit)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseUnix(%q, %v) = %s, %v, want %s", tt.s, tt.unit, got, err, tt.want)
+			continue
+		}
+		if s := FormatUnix(got, tt.unit); s != tt.s {
+			t.Errorf("FormatUnix(%s, %v) = %q, want %q", got, tt.unit, s, tt.s)
+		}
+	}
+
+	for _, s := range []string{"", "abc", "1.", "1.5e3", "--1"} {
+		if got, err := ParseUnix(s, time.Second); err == nil {
+			t.Errorf("ParseUnix(%q) = %s, want error", s, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gnss
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WeekDir returns the conventional GPS week directory of date under root,
+// e.g. "products/2313/". The date is a calendar date, taken in GPS time as
+// the names of the products, so that the week does not change seconds
+// before midnight by the leap seconds.
+func WeekDir(root string, date time.Time) (string, error) {
+	g, err := FromSystemTime(SYSGPS, date.UTC())
+	if err != nil {
+		return "", err
+	}
+	return WeekDirOf(root, g.Week), nil
+}
+
+// WeekDirOf returns the directory of GPS week under root, e.g. "products/2313/".
+func WeekDirOf(root string, week int) string {
+	return path.Join(root, fmt.Sprintf("%04d", week)) + "/"
+}
+
+// ParseWeekDir returns the GPS week and its first day (Sunday) from the last
+// week-numbered element of dir, e.g. "products/2313/" or "/pub/2313/igs23130.sp3".
+// A week is an element of 4 digits; one followed by an element of 3 digits is
+// the year of a YYYY/DDD directory, e.g. "data/2024/123", and is not a week.
+func ParseWeekDir(dir string) (week int, start time.Time, err error) {
+	elems := strings.Split(strings.Trim(dir, "/"), "/")
+	for i := len(elems) - 1; i >= 0; i-- {
+		if !isDigits(elems[i], 4) || i+1 < len(elems) && isDigits(elems[i+1], 3) {
+			continue
+		}
+		if week, err = strconv.Atoi(elems[i]); err != nil {
+			continue
+		}
+		return week, GPST0.AddDate(0, 0, 7*week), nil
+	}
+	return 0, time.Time{}, fmt.Errorf("no GPS week directory in %s", dir)
+}
+
+// isDigits reports whether s consists of n decimal digits.
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package gnss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekDir(t *testing.T) {
+	tests := []struct {
+		root string
+		date time.Time
+		want string
+	}{
+		{"products", date(2024, 5, 2), "products/2312/"},
+		{"products", date(2024, 5, 4).Add(oneDay - time.Second), "products/2312/"},
+		{"products", date(2024, 5, 5), "products/2313/"},
+		{"/pub/", date(1980, 1, 6), "/pub/0000/"},
+		{"", date(1999, 8, 22), "1024/"},
+		// the date in another zone is the date in UTC
+		{"products", time.Date(2024, 5, 5, 1, 0, 0, 0, time.FixedZone("JST", 9*3600)), "products/2312/"},
+	}
+	for _, tt := range tests {
+		got, err := WeekDir(tt.root, tt.date)
+		if err != nil || got != tt.want {
+			t.Errorf("WeekDir(%q, %v) = %q, %v, want %q", tt.root, tt.date, got, err, tt.want)
+		}
+	}
+
+	if _, err := WeekDir("products", date(1980, 1, 5)); err == nil {
+		t.Errorf("WeekDir before GPST0 succeeded, want error")
+	}
+}
+
+func TestParseWeekDir(t *testing.T) {
+	tests := []struct {
+		dir  string
+		week int
+	}{
+		{"products/2313/", 2313},
+		{"/pub/2313/igs23130.sp3", 2313},
+		{"2313", 2313},
+		{"/pub/0100/", 100},
+		{"products/2313/2024/123/", 2313},
+		{"data/2024/123/2313/", 2313},
+	}
+	for _, tt := range tests {
+		week, start, err := ParseWeekDir(tt.dir)
+		if err != nil || week != tt.week || !start.Equal(GPST0.AddDate(0, 0, 7*tt.week)) {
+			t.Errorf("ParseWeekDir(%q) = %d, %v, %v, want %d", tt.dir, week, start, err, tt.week)
+		}
+	}
+
+	for _, dir := range []string{
+		"data/2024/123",
+		"data/2024/123/",
+		"/pub/igs23130.sp3",
+		"products/231x/",
+		"products/+231/",
+		"",
+	} {
+		if week, _, err := ParseWeekDir(dir); err == nil {
+			t.Errorf("ParseWeekDir(%q) = %d, want error", dir, week)
+		}
+	}
+}
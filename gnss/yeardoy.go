@@ -0,0 +1,55 @@
+package gnss
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// YearDoy is a date expressed in year and day of year.
+type YearDoy struct {
+	Year int
+	Doy  int
+}
+
+// YearDoyOf returns YearDoy of the date of t in UTC.
+func YearDoyOf(t time.Time) YearDoy {
+	t = t.UTC()
+	return YearDoy{Year: t.Year(), Doy: t.YearDay()}
+}
+
+// Time returns the date at 00:00 UTC.
+func (d YearDoy) Time() time.Time {
+	return time.Date(d.Year, time.January, d.Doy, 0, 0, 0, 0, time.UTC)
+}
+
+// Valid reports whether d is a valid date.
+func (d YearDoy) Valid() bool {
+	return validDoy(d.Year, d.Doy)
+}
+
+// String returns d in the form of "yyyy:ddd", e.g. "2024:123".
+func (d YearDoy) String() string {
+	return fmt.Sprintf("%04d:%03d", d.Year, d.Doy)
+}
+
+// ParseYearDoy parses a date in the form of "yyyy:ddd", e.g. "2024:123".
+// The separator may be ':', '-', '/', '_', or a space.
+func ParseYearDoy(s string) (YearDoy, error) {
+	var buf fieldBuf
+	f, at := buf.split(s)
+	if len(f) != 2 {
+		return YearDoy{}, parseError(s, "", -1, "invalid year:doy: '%s'", s)
+	}
+
+	year, err := strconv.Atoi(f[0])
+	if err != nil {
+		return YearDoy{}, parseError(s, "", at[0], "invalid year: '%s' in '%s'", f[0], s)
+	}
+	doy, err := strconv.Atoi(f[1])
+	if err != nil || !validDoy(year, doy) {
+		return YearDoy{}, parseError(s, "", at[1], "invalid doy: '%s' in '%s'", f[1], s)
+	}
+
+	return YearDoy{Year: year, Doy: doy}, nil
+}
@@ -26,393 +26,655 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"log/slog"
+	"os"
 	"strconv"
 	"strings"
 	"time"
-)
 
-// constants
-// The first day of each satellite system to count week number
-var (
-	GPST0  time.Time = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
-	GST0   time.Time = time.Date(1999, time.August, 22, 0, 0, 0, 0, time.UTC)
-	QZSST0 time.Time = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
-	BDT0   time.Time = time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)
+	"github.com/satoshi-pes/gnsscal/calendar"
+	"github.com/satoshi-pes/gnsscal/gnss"
 )
 
 // durations
 var oneDay time.Duration = time.Duration(time.Hour * 24)
 var oneWeek time.Duration = time.Duration(oneDay * 7)
 
-// highlight colors
-const (
-	H1 = "  \033[7m%2d\033[0m" // reversed color (default)
-	H2 = "  \033[4m%2d\033[0m" // underline
-)
-
-type gnssCal struct {
-	SatSys    SatSys
-	Highlight bool
-	RefDate   time.Time
-	Layout    calLayout
-	SysTime0  time.Time
-	Today     time.Time
+// logger receives the diagnostics of the command, enabled by -v.
+var logger = slog.New(slog.DiscardHandler)
+
+// calFlags are the flags of the calendar
+type calFlags struct {
+	satsys      string
+	threeMonth  bool
+	quarter     bool
+	weekRows    bool
+	plain       bool
+	noHighlight bool
+	strict      bool
+	progress    bool
+	dow         bool
+	cell        string
+	julian      bool
+	chart       bool
+	columns     int
+	leap        bool
+	events      bool
+	eclipse     bool
+	footer      bool
+	border      bool
+	color       string
+	wholeWeek   bool
+	kp          float64
+	f107        float64
+	watch       bool
+	checkLeap   bool
+	refresh     bool
+	offset      bool
+	moscow      bool
+	locale      string
+	era         bool
+	firstDay    string
 }
 
-type calLayout int
-
-const (
-	Layout1Month calLayout = iota
-	Layout3Month
-	Layout1Year
-)
-
-type SatSys string
-
-const (
-	SYSGPS SatSys = "GPS"
-	SYSGLO SatSys = "GLO"
-	SYSGAL SatSys = "GAL"
-	SYSQZS SatSys = "QZS"
-	SYSBDS SatSys = "BDS"
-)
-
-// flags
-var (
-	flagSatsys      string
-	flag3mon        bool
-	flagNoHighlight bool
-	flagShowHelp    bool
-)
-
-func init() {
-	flag.StringVar(&flagSatsys, "satsys", "GPS", "satellite system of GNSS week to be shown")
-	flag.BoolVar(&flag3mon, "3", false, "three month layout")
-	flag.BoolVar(&flagNoHighlight, "n", false, "turns off lighlight of today")
-
-	flag.Usage = func() {
-		w := flag.CommandLine.Output()
-
-		fmt.Fprintf(w, "%s\n", helpMsg)
+// newCalFlagSet returns the flag set of the calendar, which is not
+// registered to flag.CommandLine so that other programs can embed gnsscal.
+func newCalFlagSet() (*flag.FlagSet, *calFlags) {
+	f := &calFlags{}
+	fs := flag.NewFlagSet("gnsscal", flag.ExitOnError)
+	fs.StringVar(&f.satsys, "satsys", "GPS", "satellite system of GNSS week to be shown")
+	fs.BoolVar(&f.threeMonth, "3", false, "three month layout")
+	fs.BoolVar(&f.quarter, "q", false, "quarter layout")
+	fs.BoolVar(&f.weekRows, "weeks", false, "layout of one GNSS week per row across month boundaries")
+	fs.BoolVar(&f.noHighlight, "n", false, "turns off lighlight of today")
+	fs.StringVar(&f.color, "color", "auto", "writes escape sequences of highlights; 'auto' to terminals, 'always', or 'never'")
+	fs.BoolVar(&f.wholeWeek, "W", false, "highlights the other days of the GNSS week of today too")
+	fs.BoolVar(&f.strict, "strict", true, "reject unknown satellite systems instead of falling back to GPS")
+	fs.BoolVar(&f.progress, "p", false, "shows progress of the current GNSS week")
+	fs.BoolVar(&f.dow, "d", false, "shows GNSS day of week for each day")
+	fs.IntVar(&f.columns, "columns", 3, "number of months per row in one year layout")
+	fs.BoolVar(&f.chart, "chart", false, "wall-chart layout of doy for one year")
+	fs.BoolVar(&f.plain, "plain", false, "one labeled line per day instead of a grid, for screen readers")
+	fs.BoolVar(&f.julian, "j", false, "shows doy instead of day of month")
+	fs.StringVar(&f.cell, "cell", "day", "contents of day cells; 'day', 'wd', or 'both'")
+	fs.BoolVar(&f.leap, "leap", false, "marks days on which a leap second is inserted")
+	fs.BoolVar(&f.events, "events", false, "marks notable GNSS events, e.g. week rollovers, with a footnote listing them")
+	fs.BoolVar(&f.eclipse, "eclipse", false, "shades GNSS weeks of approximate eclipse seasons of GPS or GAL orbital planes")
+	fs.BoolVar(&f.border, "border", false, "draws months and week rows in boxes of box-drawing characters")
+	fs.BoolVar(&f.footer, "footer", false, "adds a line of the GNSS weeks, doy and days under each month")
+	fs.Float64Var(&f.kp, "kp", 0, "colors days of which the largest Kp is this or more, e.g. 5 for storms")
+	fs.Float64Var(&f.f107, "f107", 0, "colors days of which the F10.7 solar flux is this or more, e.g. 150")
+	fs.BoolVar(&f.watch, "watch", false, "prints the calendar again when the day changes, until interrupted")
+	fs.BoolVar(&f.checkLeap, "check-leap", false, "checks leap seconds scheduled in the period with IERS data")
+	fs.BoolVar(&f.refresh, "refresh", false, "downloads the data of -check-leap, -kp and -f107 again ignoring the cache")
+	fs.BoolVar(&f.offset, "offset", false, "shows the offset of the system time from UTC next to week numbers")
+	fs.BoolVar(&f.moscow, "moscow", false, "day boundaries follow Moscow time (UTC+3) for GLO")
+	fs.BoolVar(&f.era, "era", false, "shows Japanese era years next to years in headers, e.g. '2024 (令和6)'")
+	fs.StringVar(&f.firstDay, "first-day", "", "weekday of the first column, e.g. 'mon' or 'sat' [default: of the locale]")
+	fs.StringVar(&f.locale, "locale", "", "language of month and weekday names, e.g. 'ja', 'de', 'fr', or 'C' [default: $LC_ALL, $LC_TIME, or $LANG]")
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "%s\n", helpMsg)
 	}
+	return fs, f
 }
 
 const helpMsg = `
 gnsscal - displays a GNSS calendar
 
 Usage:
-  gnsscal [Flags] [[month] year]
+  gnsscal [Flags] [[month] year | yyyy-mm | yyyy-yyyy] [yyyy-mm-dd]
+  gnsscal [-v] command [flags] [args]
+  gnsscal now [-satsys sys] [-moscow] [-badge | -oneline [-template layout]]
+  gnsscal clock [-satsys sys] [-panel] [-interval d] [-n count]
+  gnsscal query [-satsys sys] [-parse layout | -unix unit] [-tai | -bdt] [-finals file|url [-refresh]] [-moscow] [-dates style] [-format layout | -json | -explain] epoch... | [-jobs n] -
+  gnsscal upcoming
+  gnsscal weeks [-satsys sys] [-dates style] [year]
+  gnsscal table [-satsys sys] [-format text|csv|xlsx|parquet] [-sheets month|flat] [-dates style] [-jobs n] [year...]
+  gnsscal sessions [yyyy-mm-dd]
+  gnsscal planner [-satsys sys] [-sessions s,...] [-type t] [-format text|csv] [-dates style] from to [station...]
+  gnsscal schedule [-satsys sys] [-format text|csv] [-dates style] rule from to
+  gnsscal rinex2 [-session s] [-type t] station [yyyy-mm-dd]
+  gnsscal rinex3 [flags] station [yyyy-mm-dd]
+  gnsscal rinexinfo filename...
+  gnsscal missing [-satsys sys] [-type t] [-format text|csv] [-dates style] dir from to [station...]
+  gnsscal organize [-tree doy|week] [-link] [-n] dest file|dir...
+  gnsscal igs [-ac ac] [-ext ext [-weekly]] [yyyy-mm-dd]
+  gnsscal igs -long [-ac AC] [-campaign CMP] [-solution SOL] [yyyy-mm-dd]
+  gnsscal archive [-archive name] [-type type] [-hour hh] [-path] [yyyy-mm-dd]
+  gnsscal weekdir [-root dir] [yyyy-mm-dd | week | path]
+  gnsscal latency [yyyy-mm-dd]
+  gnsscal leapsec [-format text|json]
+  gnsscal diff [-satsys sys] epoch1 epoch2
+  gnsscal offsets [yyyy-mm-dd]
+  gnsscal convweek [-from sys] [-to sys] week...
+  gnsscal serve [-addr host:port] [-events file] [-cache n]
+  gnsscal gen-site [-years yyyy[-yyyy]] [-out dir] [-satsys sys]
+  gnsscal mcp
+  gnsscal grpc [-addr host:port]
 
 Description:
   The gnsscal displays a calendar similar to 'cal' command except for displaying 
   gnss week and doy. For default, gnsscal displays only the current month.
   If month or year is given, print the specified month / year. In the case only
   the year is specified, a gnss calender for one year period is displayed.
+  A month is also given as yyyy-mm or yyyy/mm, e.g. 2024-03 for '3 2024', and
+  a range of years, e.g. 1980-2100, displays the years one after another.
+  A date, yyyy-mm-dd or yyyymmdd, in any position among the arguments and flags
+  is highlighted instead of today, and its month is shown if no month is given.
 
 Flags:
   -h        help for gnsscal
+  -v        prints diagnostics (parsing decisions, downloads, cache) to stderr; given
+            before a command to apply to it, e.g. 'gnsscal -v query 2024:123'
   -n        turns off highlight of today [default: highlight on]
+  -color    highlights with escape sequences; 'auto' if stdout is a terminal, NO_COLOR
+            is not set, and TERM is not dumb, 'always' (e.g. for 'less -R'), or 'never'
+            [default: auto]. Without them, today is marked by brackets, e.g. '[15]',
+            or an asterisk, and the other marks are only in the footnotes. Escape
+            sequences are enabled on Windows 10 consoles and later
+  -W        highlights the other days of the GNSS week of today (or of the date given)
+            in blue, e.g. for the work on weekly products
   -3        three-month layout that displays previous, current and next months
-  -satsys   satellite system of GNSS week; 'GPS', 'QZS', 'GAL', 'BDS', or 'GLO' [default: GPS]
+  -q        quarter layout that displays three months of the calendar quarter
+  -weeks    layout of one GNSS week per row continuing across month boundaries,
+            with month names in the margin
+  -d        shows GNSS day of week (0-6) used in product filenames under each day
+  -columns  number of months per row in one year layout; 1, 2, 3, 4, 6, or 12 [default: 3]
+  -chart    wall-chart layout of one year with months as columns and days as rows,
+            each cell showing doy (and GNSS week/dow with -cell)
+  -plain    one labeled line per day of the month or year instead of a grid, e.g.
+            '2024-03-15, Friday, DOY 075, GPS week 2305 day 5', for screen readers
+            and line-oriented diffs; today and leap second days (-leap) are labeled
+  -j        shows doy in day cells instead of day of month, as 'cal -j' does
+  -cell     contents of day cells; 'day' for day of month, 'wd' for GNSS week/dow
+            (e.g. 2300/3) instead of day of month, or 'both' [default: day]
+  -p        shows day of week, seconds of week and progress of the current GNSS week
+  -watch    clears the screen and prints the calendar again when the day changes
+            (every minute with -p), until interrupted, for monitors running gnsscal
+  -leap     underlines days on which a leap second is inserted (23:59:60 UTC),
+            with a footnote listing them
+  -events   marks days of notable GNSS events in bold, with a footnote listing them;
+            the epochs and week rollovers of the systems (e.g. GPS 1999, 2019 and
+            2038) and the declarations of their services (e.g. GPS FOC in 1995)
+  -eclipse  shades the week numbers of GNSS weeks touching eclipse seasons (low beta
+            angles) of the orbital planes of GPS (A-F) or GAL (A-C), with a footnote
+            listing them; approximate by a few days from the nominal planes
+  -footer   adds a line under each month of its range of GNSS weeks and doy and the
+            number of days, e.g. 'W2303-2308 DOY 061-091 31 days'
+  -border   draws months (and the rows of -weeks) in boxes of box-drawing characters
+            with lines separating the header and the week rows, for screenshots
+  -kp       colors days of which the largest 3-hourly Kp is the value or more (e.g. 5
+            for geomagnetic storms), with a footnote listing them; the indices of
+            GFZ Potsdam and the 27-day outlook of NOAA SWPC are downloaded to the
+            cache directory and refreshed daily
+  -f107     colors days of which the F10.7 solar radio flux is the value or more
+            (e.g. 150), as -kp does; days matching either are colored
+  -offset   shows the offset from UTC applied to each week row (e.g. GPS-UTC '+18s')
+            next to the week number
+  -moscow   day boundaries (today, doy) follow Moscow time (UTC+3) as GLONASS days do;
+            only with -satsys GLO
+  -locale   language of month and weekday names; 'en', 'de', 'fr', 'es', 'it', 'pt', 'nl',
+            'ja', 'zh', or 'ko'. 'ja_JP.UTF-8' etc. are also accepted. Weeks start on
+            Monday for de, fr, es, it, pt and nl. Use -locale C to force English with
+            Sunday first [default: $LC_ALL, $LC_TIME, or $LANG, else C]
+  -first-day
+            weekday of the first column, e.g. 'mon', or 'sat' as in the Gulf region
+            [default: of -locale]. GNSS week numbers stay on their Sunday boundaries;
+            a row spanning two weeks shows the week of most of its days
+  -era      shows Japanese era years next to years in headers, e.g. '2024 (令和6)', or
+            '2019 (平成31/令和元)' across the change of era
+  -check-leap
+            warns if a leap second is scheduled in the period shown, according to
+            leap-seconds.list of IERS cached in the cache directory (refreshed
+            when older than 30 days or expired)
+  -refresh  downloads the data of -check-leap, -kp and -f107 again ignoring the cache;
+            the cache directory is $GNSSCAL_CACHE_DIR, or gnsscal in $XDG_CACHE_HOME
+            (~/.cache)
+  -satsys   satellite system of GNSS week; 'GPS', 'QZS', 'GAL', 'BDS', 'GLO', or 'IRN' [default: GPS]
+            RINEX system codes 'G', 'J', 'E', 'C', 'R', and 'I' are also accepted
+            as well as names such as 'Galileo' or 'BeiDou', in any case
+  -strict   reject unknown satellite systems; use -strict=false to fall back to GPS [default: true]
+
+Commands:
+  now       displays the current GNSS time, including the progress of the week
+            (or an SVG badge, e.g. 'GPS week 2313 / DOY 123', with -badge, or a line
+            for tmux status bars and prompts, e.g. 'W2313 D4 DOY124', with -oneline;
+            -template sets its layout with the verbs of query -format)
+  clock     shows UTC, the system time, GNSS week/sow and doy ticking in real time on
+            a line (or a panel with -panel) until interrupted, for ops consoles
+  query     displays the GNSS time of epochs given as yyyy:ddd:sod (or hh:mm:ss),
+            wwww:d[:sow], yyyy-mm-dd [hh:mm:ss], RFC 3339, fractional MJD (MJD60432.5),
+            RINEX 2/3 and SP3 epoch records, or a custom layout
+            with GNSS verbs (%W week, %N dow, %j doy, %S sow, %M MJD, ...)
+            or read from stdin one per line with '-' for batch conversions,
+            converted by -jobs goroutines (all cores by default) keeping the order
+            ('-dates ordinal' writes ISO 8601 ordinal dates, e.g. 2024-123, and
+            '-dates week' ISO week dates, e.g. 2024-W18-4, as do weeks and table;
+            -explain shows the steps: leap seconds, epoch, days and weeks since it)
+  upcoming  lists the next week rollovers and announced leap second
+  weeks     lists GNSS weeks touching a year with their start/end dates and doy range
+  table     prints doy and GNSS week/dow of every day of the given years
+            (-format xlsx writes an Excel workbook, a sheet per month or, with
+            -sheets flat, of all days, highlighting today and GNSS events, and
+            -format parquet a Parquet file of a row group per year, for Spark/DuckDB)
+  sessions  prints the hourly RINEX session letters (a-x, and 0 for daily) of a day
+  planner   prints the planning table of a campaign from a date to another: date, doy,
+            GNSS week/dow, and a column of RINEX v2 filenames per station and session
+            (-sessions, e.g. 0 or a,b,c), or of doy and session letter without stations
+  schedule  prints the dates from a date to another matching a rule with their doy and
+            GNSS week/dow, e.g. for periodic reprocessing jobs; 'every:n' for every n-th
+            day, 'dow:d' for day d of GNSS weeks, or 'monthday:d' for day d of months
+            (-1 for the last)
+  rinex2    prints the RINEX v2 short filename (ssssdddf.yyt) of a station and date
+  rinex3    prints the RINEX v3/v4 long filename of a station and date
+  rinexinfo prints the station, date, session, GNSS week and doy of RINEX filenames
+  missing   scans a data directory for RINEX filenames and lists the days from a date
+            to another without files per station, with their doy and GNSS week/dow
+  organize  moves (or symlinks with -link) RINEX and IGS product files into the YYYY/DDD
+            or GPS week (-tree week) directory tree by the dates of their filenames;
+            -n prints the moves without making them
+  igs       prints the IGS product filenames of a date; legacy names (e.g. igswwwwd.sp3)
+            by default, or long names (e.g. IGS0OPSFIN_20243350000_01D_15M_ORB.SP3) with -long
+  archive   prints the remote directories of CDDIS, IGN and BKG archives for a date
+  weekdir   prints the GPS week directory (e.g. products/2313/) of a date or week,
+            or the dates of a GPS week directory
+  latency   prints when ultra-rapid, rapid and final IGS products covering a date
+            become available
+  leapsec   prints all leap seconds with the GPS week and the TAI-UTC and GPS-UTC offsets
+  diff      prints the difference between two epochs in days, GNSS weeks and days,
+            and seconds in UTC and in GPST (including leap seconds in between)
+  offsets   prints the offsets between UTC, TAI, GPST, GST, BDT and GLONASST of a date
+  convweek  converts week numbers between systems, e.g. GPS 2300 to GAL 1276 and BDS 944
+  serve     runs an HTTP server rendering HTML calendars at /YYYY and /YYYY/MM, with
+            satsys, layout and cell selectable by query parameters
+            and a JSON API at /api/v1/convert, /api/v1/date/{date}, /api/v1/week/{week}
+            and /api/v1/month/{yyyy}/{mm} (month grids for frontends)
+            and an iCalendar subscription of GNSS weeks at /ics/YYYY.ics
+            and Prometheus metrics of the current GNSS week, dow, sow and doy at /metrics
+            and an SVG badge of the current GNSS week and doy at /badge
+            and Atom/RSS feeds of upcoming rollovers, leap seconds and campaign events
+            (-events file) at /feed.atom and /feed.rss
+            with pages and API responses cached in memory until the day changes and
+            served with ETag/Last-Modified for conditional requests
+  gen-site  writes static HTML calendars of years and months (as serve renders)
+            to a directory for hosting on a web server
+  mcp       serves the conversions (convert, date, week) as tools by JSON-RPC 2.0 on
+            stdin/stdout, as a Model Context Protocol (MCP) server for editors and agents
+  grpc      runs the gRPC server of gnsscal.v1.GnssCal (built with -tags grpc)
 
   Created by Satoshi Kawamoto <satoshi.pes@gmail.com> October 16, 2021
   Inspired by 'gpscal' created by Dr. Yuki Hatanaka
 `
 
-func getCalWithOpt() (cal gnssCal, err error) {
-	flag.Parse()
-	args := flag.Args()
+// getCalWithOpt returns the calendar specified by the command line
+// arguments args, and the flags parsed.
+func getCalWithOpt(args []string) (cal *calendar.Calendar, f *calFlags, err error) {
+	fs, f := newCalFlagSet()
+	fs.Parse(args)
+
+	// ISO dates are taken in any position as the date to be highlighted,
+	// and the flags after them are parsed
+	var date time.Time
+	var rest []string
+	for args = fs.Args(); len(args) > 0; {
+		d, ok, err := parseISODate(args[0])
+		if err != nil {
+			return nil, f, err
+		}
+		if !ok {
+			rest = append(rest, args[0])
+			args = args[1:]
+			continue
+		}
+		if !date.IsZero() {
+			return nil, f, fmt.Errorf("more than one date is given: %s and %s", date.Format(time.DateOnly), d.Format(time.DateOnly))
+		}
+		date = d
+		fs.Parse(args[1:])
+		args = fs.Args()
+	}
+	args = rest
 
-	today := time.Now().Truncate(oneDay)
+	today := time.Now().UTC().Truncate(oneDay)
+	if f.moscow {
+		// a GLONASS day starts at 21:00 UTC of the day before
+		today = gnss.MoscowDate(time.Now())
+	}
+	if !date.IsZero() {
+		today = date
+	}
 
 	// default opt
-	cal = gnssCal{
-		SatSys:    SYSGPS,
-		Highlight: true,
-		RefDate:   today,
-		Layout:    Layout1Month,
-		SysTime0:  GPST0,
-		Today:     today,
+	opts := []calendar.Option{calendar.WithToday(today)}
+
+	if err := checkCalArgs(args); err != nil {
+		return nil, f, err
 	}
 
 	switch len(args) {
 	// args [[month] year]
 	case 1:
-		// 1 year layout
-		var year int
-		year, err = strconv.Atoi(args[0])
+		if y, m, ok := cutYearMonth(args[0]); ok {
+			// one month layout given as yyyy-mm or yyyy/mm
+			month, year, err := parseMonthYear(m, y)
+			if err != nil {
+				return nil, f, err
+			}
+			opts = append(opts, monthReference(today, year, month))
+			break
+		}
 
-		// check errors
-		if err != nil || year < 1980 {
-			return cal, fmt.Errorf("invalid year: %s", args[0])
+		// 1 year layout, or years given as yyyy-yyyy
+		year, lastYear, err := parseYears(args[0])
+		if err != nil {
+			return nil, f, err
 		}
 
 		// set opts
-		cal.RefDate = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
-		cal.Layout = Layout1Year
+		opts = append(opts, calendar.WithYears(year, lastYear))
 	case 2:
 		// one month layout
-		var year, month int
-		var err error
-
-		// check errors
-		if month, err = strconv.Atoi(args[0]); err != nil {
-			return cal, fmt.Errorf("invalid month: %s, error: %v", args[0], err)
-		}
-		if year, err = strconv.Atoi(args[1]); err != nil {
-			return cal, fmt.Errorf("invalid year: %s, error: %v", args[1], err)
-		}
-		if month < 0 || 12 < month {
-			return cal, fmt.Errorf("invalid month: %d", month)
-		}
-		if year < 1980 {
-			return cal, fmt.Errorf("invalid year: %d", year)
+		month, year, err := parseMonthYear(args[0], args[1])
+		if err != nil {
+			return nil, f, err
 		}
 
 		// set opts
-		cal.Layout = Layout1Month
-		if year == today.Year() && time.Month(month) == today.Month() {
-			cal.RefDate = today
-		} else {
-			cal.RefDate = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-		}
+		opts = append(opts, monthReference(today, year, month))
 	}
 
 	// flags
-	switch flagSatsys {
-	case "GPS":
-		cal.SatSys = SYSGPS
-		cal.SysTime0 = GPST0
-	case "QZS":
-		cal.SatSys = SYSQZS
-		cal.SysTime0 = QZSST0
-	case "BDS":
-		cal.SatSys = SYSBDS
-		cal.SysTime0 = BDT0
-	case "GAL":
-		cal.SatSys = SYSGAL
-		cal.SysTime0 = GST0
-	case "GLO":
-		cal.SatSys = SYSGLO
-		cal.SysTime0 = leapYearDate(cal.RefDate) // Glonass week starts from the first day of leap year
-	default:
-		fmt.Printf("unknown SatSys: '%s'. use GPST instead.\n", flagSatsys)
+	// satellite systems are parsed by name, alias or RINEX single-letter code
+	if sys, err := lookupSatSys(f.satsys); err == nil {
+		opts = append(opts, calendar.WithSatSys(sys.Name))
+	} else {
+		if f.strict {
+			return nil, f, err
+		}
+		fmt.Fprintf(os.Stderr, "%v use GPST instead.\n", err)
 	}
 
-	if flag3mon {
-		cal.Layout = Layout3Month
+	if f.threeMonth {
+		opts = append(opts, calendar.WithLayout(calendar.Layout3Month))
 	}
 
-	if flagNoHighlight {
-		cal.Highlight = false
+	if f.quarter {
+		opts = append(opts, calendar.WithLayout(calendar.LayoutQuarter))
 	}
 
-	return cal, nil
-}
+	if f.weekRows {
+		opts = append(opts, calendar.WithLayout(calendar.LayoutWeekRows))
+	}
 
-func main() {
-	cal, err := getCalWithOpt()
-	if err != nil {
-		fmt.Printf("%v\n", err)
-		return
+	if f.chart {
+		opts = append(opts, calendar.WithLayout(calendar.LayoutYearChart))
 	}
 
-	// print gnss calendar
-	fmt.Printf("%s\n", cal.String())
-}
+	if f.plain {
+		opts = append(opts, calendar.WithLayout(calendar.LayoutPlain))
+	}
 
-func (c gnssCal) String() string {
-	var msg []string
-	switch c.Layout {
-	case Layout1Month:
-		msg = c.OneMonthLayout()
-	case Layout3Month:
-		msg = c.ThreeMonthLayout()
-	case Layout1Year:
-		msg = c.OneYearLayout()
+	if f.events {
+		opts = append(opts, calendar.WithEvents(calendar.GNSSEvents()))
 	}
 
-	return strings.Join(msg, "\n")
-}
+	cell, ok := cellNames[f.cell]
+	if !ok {
+		return nil, f, fmt.Errorf("invalid cell format: '%s'. valid formats: day, wd, both", f.cell)
+	}
 
-func (c gnssCal) OneMonthLayout() (msg []string) {
-	refDate := c.RefDate
-	return gnssCalMonth(refDate.Year(), refDate.Month(), c.Today, c.Highlight, c.SysTime0, c.SatSys)
-}
+	locale, ok := calendar.LookupLocale(f.locale)
+	if f.locale == "" {
+		// detected from the environment; unknown languages fall back to C
+		name := envLocale()
+		if locale, ok = calendar.LookupLocale(name); !ok {
+			logger.Debug("no names of the locale, using C", "locale", name)
+			locale, ok = calendar.English, true
+		}
+	}
+	if !ok {
+		return nil, f, fmt.Errorf("invalid locale: '%s'. valid locales: %s, C", f.locale, strings.Join(calendar.LocaleNames(), ", "))
+	}
 
-func (c gnssCal) OneYearLayout() (msg []string) {
-	year := c.RefDate.Year()
-	today := c.Today
-	refDate1 := time.Date(year, 2, 1, 0, 0, 0, 0, time.UTC)
-	refDate2 := time.Date(year, 5, 1, 0, 0, 0, 0, time.UTC)
-	refDate3 := time.Date(year, 8, 1, 0, 0, 0, 0, time.UTC)
-	refDate4 := time.Date(year, 11, 1, 0, 0, 0, 0, time.UTC)
-
-	// stack 4 rows
-	msg = append(msg, threeMonthLayout(refDate1, today, c.Highlight, c.SysTime0, c.SatSys)...)
-	msg = append(msg, "")
-	msg = append(msg, threeMonthLayout(refDate2, today, c.Highlight, c.SysTime0, c.SatSys)...)
-	msg = append(msg, "")
-	msg = append(msg, threeMonthLayout(refDate3, today, c.Highlight, c.SysTime0, c.SatSys)...)
-	msg = append(msg, "")
-	msg = append(msg, threeMonthLayout(refDate4, today, c.Highlight, c.SysTime0, c.SatSys)...)
-
-	return msg
-}
+	color, err := useColor(f.color)
+	if err != nil {
+		return nil, f, err
+	}
 
-func (c gnssCal) ThreeMonthLayout() (msg []string) {
-	return threeMonthLayout(c.RefDate, c.Today, c.Highlight, c.SysTime0, c.SatSys)
-}
+	firstDay := locale.FirstWeekday
+	if f.firstDay != "" {
+		if firstDay, err = parseWeekday(f.firstDay); err != nil {
+			return nil, f, err
+		}
+	}
 
-func threeMonthLayout(refDate, today time.Time, highlight bool, initialDate time.Time, sys SatSys) (msg []string) {
-	// for three-month layout
-	msgc := gnssCalMonth(refDate.Year(), refDate.Month(), today, highlight, initialDate, sys)
+	opts = append(opts,
+		calendar.WithColumns(f.columns),
+		calendar.WithHighlight(!f.noHighlight),
+		calendar.WithWholeWeek(f.wholeWeek),
+		calendar.WithColor(color),
+		calendar.WithDow(f.dow),
+		calendar.WithJulian(f.julian),
+		calendar.WithLeap(f.leap),
+		calendar.WithEclipse(f.eclipse),
+		calendar.WithFooter(f.footer),
+		calendar.WithBorder(f.border),
+		calendar.WithOffset(f.offset),
+		calendar.WithMoscow(f.moscow),
+		calendar.WithCell(cell),
+		calendar.WithLocale(locale),
+		calendar.WithEra(f.era),
+		calendar.WithFirstWeekday(firstDay),
+	)
+
+	cal, err = calendar.NewCalendar(opts...)
+	return cal, f, err
+}
 
-	var msgl, msgr []string
-	lastmonth := firstDayOfLastMonth(refDate)
-	nextmonth := firstDayOfNextMonth(refDate)
-	if sys == SYSGLO {
-		msgl = gnssCalMonth(lastmonth.Year(), lastmonth.Month(), today, highlight, leapYearDate(lastmonth), sys)
-		msgr = gnssCalMonth(nextmonth.Year(), nextmonth.Month(), today, highlight, leapYearDate(nextmonth), sys)
-	} else {
-		msgl = gnssCalMonth(lastmonth.Year(), lastmonth.Month(), today, highlight, initialDate, sys)
-		msgr = gnssCalMonth(nextmonth.Year(), nextmonth.Month(), today, highlight, initialDate, sys)
+// parseISODate parses an argument of an ISO 8601 date, yyyy-mm-dd or
+// yyyymmdd. ok is false if arg is not of the forms, and an error is
+// returned if it is but of an invalid date.
+func parseISODate(arg string) (date time.Time, ok bool, err error) {
+	layout := ""
+	switch {
+	case len(arg) == 10 && arg[4] == '-' && arg[7] == '-':
+		layout = "2006-01-02"
+	case len(arg) == 8 && strings.Trim(arg, "0123456789") == "":
+		layout = "20060102"
+	default:
+		return time.Time{}, false, nil
 	}
-
-	// check number of lines
-	N := len(msgl)
-	if len(msgc) > N {
-		N = len(msgc)
+	if date, err = time.Parse(layout, arg); err != nil {
+		return time.Time{}, true, fmt.Errorf("invalid date: '%s'", arg)
 	}
-	if len(msgr) > N {
-		N = len(msgc)
+	if date.Year() < 1980 {
+		return time.Time{}, true, fmt.Errorf("invalid date: %s; calendars start in 1980, the year of the GPS epoch", arg)
 	}
+	return date, true, nil
+}
 
-	var buf string
-	for i := 0; i < N; i++ {
-		// leftside
-		if len(msgl) > i {
-			buf += fmt.Sprintf("%-34s", msgl[i])
-		} else {
-			buf += fmt.Sprintf("%34s", "")
-		}
-		buf += fmt.Sprintf("    ")
-
-		// center
-		if len(msgc) > i {
-			buf += fmt.Sprintf("%-34s", msgc[i])
-		} else {
-			buf += fmt.Sprintf("%34s", "")
+// checkCalArgs reports an error for more arguments than [[month] year],
+// telling flags given after them, which the flag package does not parse.
+func checkCalArgs(args []string) error {
+	for _, arg := range args {
+		if len(arg) > 1 && arg[0] == '-' {
+			return fmt.Errorf("flag '%s' is given after the arguments; flags must come before [[month] year], e.g. gnsscal -3 3 2024", arg)
 		}
-		buf += fmt.Sprintf("    ")
+	}
+	if len(args) > 2 {
+		return fmt.Errorf("too many arguments: '%s'; give [[month] year], e.g. gnsscal 3 2024", strings.Join(args, " "))
+	}
+	return nil
+}
 
-		// right side
-		if len(msgr) > i {
-			buf += fmt.Sprintf("%-34s", msgr[i])
-		} else {
-			buf += fmt.Sprintf("%34s", "")
-		}
-		msg = append(msg, buf)
-		buf = ""
+// monthReference returns the option of the reference date of the month
+// of year; today if in the month, to be highlighted, or else the first day.
+func monthReference(today time.Time, year int, month time.Month) calendar.Option {
+	if year == today.Year() && month == today.Month() {
+		return calendar.WithReference(today)
 	}
+	return calendar.WithReference(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+}
 
-	return
+// cutYearMonth cuts a month argument given as yyyy-mm or yyyy/mm, with a
+// month of 1 or 2 digits unlike a range of years yyyy-yyyy.
+func cutYearMonth(arg string) (year, month string, ok bool) {
+	year, month, ok = strings.Cut(arg, "-")
+	if !ok {
+		year, month, ok = strings.Cut(arg, "/")
+	}
+	return year, month, ok && 1 <= len(month) && len(month) <= 2
 }
 
-// gnssCalMonth returns calendar msg for a month.
-//
-// 'year', 'month' specify the month to be shown.
-// If 'highlight' is true, 'today' is highlighted.
-// GNSS week is calculated based on the 'initialDate'.
-//
-// Note that the initialDate may not start from Sunday for GLONASS.
-// So the week numbers are calculated at first day of the month and
-// Sundays, and the same week numbers could be printed.
-func gnssCalMonth(year int, month time.Month, today time.Time, highlight bool, initialDate time.Time, sys SatSys) (msg []string) {
-	var bufday, bufdoy string
-
-	// prepare
-	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
-	lastDay := firstDayOfNextMonth(firstDay)
-
-	// print header
-	head := fmt.Sprintf("%s %4d", month.String(), year)
-	msg = append(msg, fmt.Sprintf(fmt.Sprintf("%%s%%%ds", 17+len(head)/2), sys, head)) // centering message
-	msg = append(msg, "Week   Sun Mon Tue Wed Thu Fri Sat")
-
-	// print dates
-	for date := firstDay; date.Before(lastDay); date = date.Add(oneDay) {
-		if date.Equal(firstDay) || date.Weekday() == time.Sunday {
-			// calculate GNSS week
-			if date.Before(initialDate) {
-				bufday += "      "
-			} else {
-				bufday += fmt.Sprintf("%4d  ", gnssWeek(date, initialDate))
-			}
-			bufdoy += "      "
-			for i := 0; i < int(date.Weekday()); i++ {
-				bufday += "    "
-				bufdoy += "    "
-			}
-		}
+// parseYears parses the year argument, yyyy or a range of years yyyy-yyyy.
+func parseYears(arg string) (first, last int, err error) {
+	s, e, ok := strings.Cut(arg, "-")
+	if !ok {
+		e = s
+	}
+	if first, err = parseYear(s); err != nil {
+		return 0, 0, err
+	}
+	if last, err = parseYear(e); err != nil {
+		return 0, 0, err
+	}
+	if last < first {
+		return 0, 0, fmt.Errorf("%w: %s; the last year is before the first, did you mean %d-%d?", calendar.ErrInvalidYear, arg, last, first)
+	}
+	return first, last, nil
+}
 
-		if date.Equal(today) && highlight {
-			bufday += fmt.Sprintf(H1, date.Day()) // reversed color
-		} else {
-			bufday += fmt.Sprintf("  %2d", date.Day())
-		}
-		bufdoy += fmt.Sprintf(" %03d", doy(date))
+// parseYear parses a year of calendars, from 1980, the GPS epoch, to 9999.
+func parseYear(s string) (int, error) {
+	year, err := strconv.Atoi(s)
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("%w: '%s'; give a year as yyyy, e.g. 2024, or a range of years, e.g. 2024-2025", calendar.ErrInvalidYear, s)
+	case year < 1980:
+		return 0, fmt.Errorf("%w: %d; calendars start in 1980, the year of the GPS epoch", calendar.ErrInvalidYear, year)
+	case year > 9999:
+		return 0, fmt.Errorf("%w: %d; give a year of 4 digits", calendar.ErrInvalidYear, year)
+	}
+	return year, nil
+}
 
-		if date.Weekday() == time.Saturday {
-			msg = append(msg, bufday)
-			msg = append(msg, bufdoy)
-			bufday = ""
-			bufdoy = ""
+// parseMonthYear parses the arguments of a month, month and year, and
+// suggests the correction if they are given in the order of year and month.
+func parseMonthYear(m, y string) (time.Month, int, error) {
+	month, err := strconv.Atoi(m)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: '%s'; give a month as a number from 1 to 12 before the year, e.g. gnsscal 3 2024", calendar.ErrInvalidMonth, m)
+	}
+	if month < 1 || 12 < month {
+		if n, err := strconv.Atoi(y); err == nil && 1 <= n && n <= 12 && month >= 1980 {
+			return 0, 0, fmt.Errorf("%w: %d; the month is given before the year, did you mean 'gnsscal %d %d'?", calendar.ErrInvalidMonth, month, n, month)
 		}
+		return 0, 0, fmt.Errorf("%w: %d; months are from 1 to 12", calendar.ErrInvalidMonth, month)
 	}
-
-	if lastDay.Weekday() != time.Sunday {
-		msg = append(msg, bufday)
-		msg = append(msg, bufdoy)
+	year, err := parseYear(y)
+	if err != nil {
+		return 0, 0, err
 	}
-
-	return
+	return time.Month(month), year, nil
 }
 
-func doy(date time.Time) int {
-	newYearDay := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
-	return int(date.Sub(newYearDay).Seconds()/oneDay.Seconds()) + 1
+// useColor reports whether escape sequences are written to stdout by the
+// mode of -color. With "auto", they are written to terminals processing
+// them, i.e. not if stdout is redirected, NO_COLOR is set
+// (https://no-color.org), TERM is dumb, or the Windows console does not
+// support them.
+func useColor(mode string) (bool, error) {
+	switch mode {
+	case "always":
+		enableVT(os.Stdout)
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" || !isTerminal(os.Stdout) {
+			return false, nil
+		}
+		return enableVT(os.Stdout), nil
+	}
+	return false, fmt.Errorf("invalid color mode: '%s'. valid modes: auto, always, never", mode)
 }
 
-func gnssWeek(date time.Time, initialDate time.Time) int {
-	return int(date.Sub(initialDate).Seconds() / oneWeek.Seconds())
+// isTerminal reports whether f is a terminal (a console on Windows).
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
 }
 
-func gloWeek(date time.Time) int {
-	return gnssWeek(date, leapYearDate(date))
+// parseWeekday parses a weekday given by its name or abbreviation in any
+// case, e.g. "Saturday", "sat", or "Sa", or by its number, 0 for Sunday.
+func parseWeekday(s string) (time.Weekday, error) {
+	if n, err := strconv.Atoi(s); err == nil && 0 <= n && n <= 6 {
+		return time.Weekday(n), nil
+	}
+	if len(s) >= 2 {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if strings.HasPrefix(strings.ToLower(wd.String()), strings.ToLower(s)) {
+				return wd, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: '%s'. valid weekdays: sun, mon, tue, wed, thu, fri, sat", calendar.ErrInvalidWeekday, s)
 }
 
-func leapYearDate(date time.Time) time.Time {
-	year := date.Year()
-	leapYear := year - year%4
+// envLocale returns the locale of the time format given by the environment
+// in the order of precedence of POSIX, or "C" if none is set.
+func envLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return "C"
+}
 
-	return time.Date(leapYear, 1, 1, 0, 0, 0, 0, time.UTC)
+// commands invoked by the first argument
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"now":       runNow,
+	"clock":     runClock,
+	"query":     runQuery,
+	"upcoming":  runUpcoming,
+	"weeks":     runWeeks,
+	"table":     runTable,
+	"sessions":  runSessions,
+	"planner":   runPlanner,
+	"schedule":  runSchedule,
+	"rinex2":    runRinex2,
+	"rinex3":    runRinex3,
+	"rinexinfo": runRinexInfo,
+	"missing":   runMissing,
+	"organize":  runOrganize,
+	"igs":       runIGS,
+	"archive":   runArchive,
+	"weekdir":   runWeekDir,
+	"latency":   runLatency,
+	"leapsec":   runLeapSec,
+	"diff":      runDiff,
+	"offsets":   runOffsets,
+	"convweek":  runConvWeek,
+	"serve":     runServe,
+	"gen-site":  runGenSite,
+	"mcp":       runRPC,
 }
 
-func firstDayOfNextMonth(date time.Time) time.Time {
-	if date.Month() == time.December {
-		return time.Date(date.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
-	} else {
-		return time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
-	}
+func doy(date time.Time) int {
+	return date.YearDay()
 }
 
-func firstDayOfLastMonth(date time.Time) time.Time {
-	if date.Month() == time.January {
-		return time.Date(date.Year()-1, time.December, 1, 0, 0, 0, 0, time.UTC)
-	} else {
-		return time.Date(date.Year(), date.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+// lookupSatSys returns the satellite system given by name, alias or code.
+func lookupSatSys(name string) (gnss.SystemInfo, error) {
+	sys, err := gnss.ParseSatSys(name)
+	if err != nil {
+		return gnss.SystemInfo{}, err
 	}
+	info, _ := gnss.Lookup(string(sys))
+	return info, nil
 }
@@ -0,0 +1,133 @@
+package gnsscalpb
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+const oneWeek = 7 * 24 * time.Hour
+
+// NewGNSSTime returns the message of g.
+func NewGNSSTime(g gnss.GNSSTime) *GNSSTime {
+	return &GNSSTime{Sys: string(g.Sys), Week: int32(g.Week), Sow: durationpb.New(g.Sow)}
+}
+
+// AsGNSSTime returns x as gnss.GNSSTime.
+// It returns an error if the satellite system is unknown, or the week or
+// the time of week is out of range.
+func (x *GNSSTime) AsGNSSTime() (gnss.GNSSTime, error) {
+	sys, err := lookupSys(x.GetSys())
+	if err != nil {
+		return gnss.GNSSTime{}, err
+	}
+	if err := x.GetSow().CheckValid(); err != nil {
+		return gnss.GNSSTime{}, err
+	}
+	sow := x.GetSow().AsDuration()
+	if x.GetWeek() < 0 || sow < 0 || sow >= oneWeek {
+		return gnss.GNSSTime{}, fmt.Errorf("invalid GNSS time: week %d, sow %v", x.GetWeek(), sow)
+	}
+	return gnss.GNSSTime{Sys: sys, Week: int(x.GetWeek()), Sow: sow}, nil
+}
+
+// AsTimestamp returns the UTC time of x as google.protobuf.Timestamp.
+// It returns an error for systems whose week counting restarts periodically
+// (GLONASS), as gnss.GNSSTime.Time does.
+func (x *GNSSTime) AsTimestamp() (*timestamppb.Timestamp, error) {
+	g, err := x.AsGNSSTime()
+	if err != nil {
+		return nil, err
+	}
+	t, err := g.Time()
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
+// GNSSTimeOf returns the GNSS time of sys of the UTC time ts.
+func GNSSTimeOf(sys gnss.SatSys, ts *timestamppb.Timestamp) (*GNSSTime, error) {
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+	g, err := gnss.TimeOf(sys, ts.AsTime())
+	if err != nil {
+		return nil, err
+	}
+	return NewGNSSTime(g), nil
+}
+
+// NewCalendarDay returns the message of d.
+func NewCalendarDay(d gnss.Day) *CalendarDay {
+	return &CalendarDay{
+		Sys:  string(d.Sys),
+		Date: timestamppb.New(d.Date),
+		Doy:  int32(d.Doy),
+		Week: int32(d.Week),
+		Dow:  int32(d.Dow),
+	}
+}
+
+// AsDay returns x as gnss.Day.
+// It returns an error if the satellite system is unknown, or the date is
+// not at 00:00 UTC.
+func (x *CalendarDay) AsDay() (gnss.Day, error) {
+	sys, err := lookupSys(x.GetSys())
+	if err != nil {
+		return gnss.Day{}, err
+	}
+	if err := x.GetDate().CheckValid(); err != nil {
+		return gnss.Day{}, err
+	}
+	date := x.GetDate().AsTime()
+	if !date.Equal(date.Truncate(24 * time.Hour)) {
+		return gnss.Day{}, fmt.Errorf("invalid calendar day: %s is not at 00:00 UTC", date.Format(time.RFC3339Nano))
+	}
+	return gnss.Day{Sys: sys, Date: date, Doy: int(x.GetDoy()), Week: int(x.GetWeek()), Dow: int(x.GetDow())}, nil
+}
+
+// MarshalGNSSTime returns the protobuf wire encoding of g.
+func MarshalGNSSTime(g gnss.GNSSTime) ([]byte, error) {
+	return proto.Marshal(NewGNSSTime(g))
+}
+
+// UnmarshalGNSSTime parses the protobuf wire encoding of a GNSSTime.
+func UnmarshalGNSSTime(b []byte) (gnss.GNSSTime, error) {
+	var x GNSSTime
+	if err := proto.Unmarshal(b, &x); err != nil {
+		return gnss.GNSSTime{}, err
+	}
+	return x.AsGNSSTime()
+}
+
+// MarshalDay returns the protobuf wire encoding of d as a CalendarDay.
+func MarshalDay(d gnss.Day) ([]byte, error) {
+	return proto.Marshal(NewCalendarDay(d))
+}
+
+// UnmarshalDay parses the protobuf wire encoding of a CalendarDay.
+func UnmarshalDay(b []byte) (gnss.Day, error) {
+	var x CalendarDay
+	if err := proto.Unmarshal(b, &x); err != nil {
+		return gnss.Day{}, err
+	}
+	return x.AsDay()
+}
+
+// lookupSys returns the satellite system of name, GPS if empty.
+func lookupSys(name string) (gnss.SatSys, error) {
+	if name == "" {
+		return gnss.SYSGPS, nil
+	}
+	s, ok := gnss.Lookup(name)
+	if !ok {
+		return "", fmt.Errorf("%w: '%s'", gnss.ErrUnknownSatSys, name)
+	}
+	return s.Name, nil
+}
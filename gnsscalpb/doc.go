@@ -0,0 +1,11 @@
+// Package gnsscalpb contains the Go code generated from
+// proto/gnsscal/v1/gnsscal.proto for the messages of the GnssCal gRPC
+// service, and from proto/gnsscal/v1/time.proto for the GNSS times and
+// calendar days with their conversions from and to the gnss package.
+//
+// The package depends only on protobuf; the stubs of the service are in
+// the package grpcpb.
+package gnsscalpb
+
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/satoshi-pes/gnsscal gnsscal/v1/gnsscal.proto
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/satoshi-pes/gnsscal gnsscal/v1/time.proto
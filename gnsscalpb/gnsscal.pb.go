@@ -0,0 +1,624 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: gnsscal/v1/gnsscal.proto
+
+package gnsscalpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TimeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Utc           string                 `protobuf:"bytes,1,opt,name=utc,proto3" json:"utc,omitempty"`
+	Satsys        string                 `protobuf:"bytes,2,opt,name=satsys,proto3" json:"satsys,omitempty"`
+	SysTime       string                 `protobuf:"bytes,3,opt,name=sys_time,json=sysTime,proto3" json:"sys_time,omitempty"`
+	Tai           string                 `protobuf:"bytes,4,opt,name=tai,proto3" json:"tai,omitempty"`
+	Week          int32                  `protobuf:"varint,5,opt,name=week,proto3" json:"week,omitempty"`
+	Dow           int32                  `protobuf:"varint,6,opt,name=dow,proto3" json:"dow,omitempty"`
+	Sow           float64                `protobuf:"fixed64,7,opt,name=sow,proto3" json:"sow,omitempty"`
+	Year          int32                  `protobuf:"varint,8,opt,name=year,proto3" json:"year,omitempty"`
+	Doy           int32                  `protobuf:"varint,9,opt,name=doy,proto3" json:"doy,omitempty"`
+	Sod           float64                `protobuf:"fixed64,10,opt,name=sod,proto3" json:"sod,omitempty"`
+	Mjd           int32                  `protobuf:"varint,11,opt,name=mjd,proto3" json:"mjd,omitempty"`
+	Unix          float64                `protobuf:"fixed64,12,opt,name=unix,proto3" json:"unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeInfo) Reset() {
+	*x = TimeInfo{}
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeInfo) ProtoMessage() {}
+
+func (x *TimeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeInfo.ProtoReflect.Descriptor instead.
+func (*TimeInfo) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_gnsscal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TimeInfo) GetUtc() string {
+	if x != nil {
+		return x.Utc
+	}
+	return ""
+}
+
+func (x *TimeInfo) GetSatsys() string {
+	if x != nil {
+		return x.Satsys
+	}
+	return ""
+}
+
+func (x *TimeInfo) GetSysTime() string {
+	if x != nil {
+		return x.SysTime
+	}
+	return ""
+}
+
+func (x *TimeInfo) GetTai() string {
+	if x != nil {
+		return x.Tai
+	}
+	return ""
+}
+
+func (x *TimeInfo) GetWeek() int32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *TimeInfo) GetDow() int32 {
+	if x != nil {
+		return x.Dow
+	}
+	return 0
+}
+
+func (x *TimeInfo) GetSow() float64 {
+	if x != nil {
+		return x.Sow
+	}
+	return 0
+}
+
+func (x *TimeInfo) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *TimeInfo) GetDoy() int32 {
+	if x != nil {
+		return x.Doy
+	}
+	return 0
+}
+
+func (x *TimeInfo) GetSod() float64 {
+	if x != nil {
+		return x.Sod
+	}
+	return 0
+}
+
+func (x *TimeInfo) GetMjd() int32 {
+	if x != nil {
+		return x.Mjd
+	}
+	return 0
+}
+
+func (x *TimeInfo) GetUnix() float64 {
+	if x != nil {
+		return x.Unix
+	}
+	return 0
+}
+
+type WeekInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Satsys        string                 `protobuf:"bytes,1,opt,name=satsys,proto3" json:"satsys,omitempty"`
+	Week          int32                  `protobuf:"varint,2,opt,name=week,proto3" json:"week,omitempty"`
+	Start         string                 `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Days          []*TimeInfo            `protobuf:"bytes,5,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeekInfo) Reset() {
+	*x = WeekInfo{}
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeekInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeekInfo) ProtoMessage() {}
+
+func (x *WeekInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeekInfo.ProtoReflect.Descriptor instead.
+func (*WeekInfo) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_gnsscal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WeekInfo) GetSatsys() string {
+	if x != nil {
+		return x.Satsys
+	}
+	return ""
+}
+
+func (x *WeekInfo) GetWeek() int32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *WeekInfo) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *WeekInfo) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *WeekInfo) GetDays() []*TimeInfo {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type ConvertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         string                 `protobuf:"bytes,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Satsys        string                 `protobuf:"bytes,2,opt,name=satsys,proto3" json:"satsys,omitempty"`
+	Parse         string                 `protobuf:"bytes,3,opt,name=parse,proto3" json:"parse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_gnsscal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ConvertRequest) GetEpoch() string {
+	if x != nil {
+		return x.Epoch
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetSatsys() string {
+	if x != nil {
+		return x.Satsys
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetParse() string {
+	if x != nil {
+		return x.Parse
+	}
+	return ""
+}
+
+type DateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Satsys        string                 `protobuf:"bytes,2,opt,name=satsys,proto3" json:"satsys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DateRequest) Reset() {
+	*x = DateRequest{}
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateRequest) ProtoMessage() {}
+
+func (x *DateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateRequest.ProtoReflect.Descriptor instead.
+func (*DateRequest) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_gnsscal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DateRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DateRequest) GetSatsys() string {
+	if x != nil {
+		return x.Satsys
+	}
+	return ""
+}
+
+type WeekRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Week          int32                  `protobuf:"varint,1,opt,name=week,proto3" json:"week,omitempty"`
+	Satsys        string                 `protobuf:"bytes,2,opt,name=satsys,proto3" json:"satsys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeekRequest) Reset() {
+	*x = WeekRequest{}
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeekRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeekRequest) ProtoMessage() {}
+
+func (x *WeekRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeekRequest.ProtoReflect.Descriptor instead.
+func (*WeekRequest) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_gnsscal_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WeekRequest) GetWeek() int32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *WeekRequest) GetSatsys() string {
+	if x != nil {
+		return x.Satsys
+	}
+	return ""
+}
+
+type CalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Year          int32                  `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
+	Month         int32                  `protobuf:"varint,2,opt,name=month,proto3" json:"month,omitempty"`
+	Satsys        string                 `protobuf:"bytes,3,opt,name=satsys,proto3" json:"satsys,omitempty"`
+	Layout        string                 `protobuf:"bytes,4,opt,name=layout,proto3" json:"layout,omitempty"`
+	Cell          string                 `protobuf:"bytes,5,opt,name=cell,proto3" json:"cell,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarRequest) Reset() {
+	*x = CalendarRequest{}
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarRequest) ProtoMessage() {}
+
+func (x *CalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarRequest.ProtoReflect.Descriptor instead.
+func (*CalendarRequest) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_gnsscal_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CalendarRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *CalendarRequest) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *CalendarRequest) GetSatsys() string {
+	if x != nil {
+		return x.Satsys
+	}
+	return ""
+}
+
+func (x *CalendarRequest) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
+func (x *CalendarRequest) GetCell() string {
+	if x != nil {
+		return x.Cell
+	}
+	return ""
+}
+
+type CalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Days          []*TimeInfo            `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarResponse) Reset() {
+	*x = CalendarResponse{}
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarResponse) ProtoMessage() {}
+
+func (x *CalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_gnsscal_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarResponse.ProtoReflect.Descriptor instead.
+func (*CalendarResponse) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_gnsscal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CalendarResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CalendarResponse) GetDays() []*TimeInfo {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+var File_gnsscal_v1_gnsscal_proto protoreflect.FileDescriptor
+
+const file_gnsscal_v1_gnsscal_proto_rawDesc = "" +
+	"\n" +
+	"\x18gnsscal/v1/gnsscal.proto\x12\n" +
+	"gnsscal.v1\"\xf7\x01\n" +
+	"\bTimeInfo\x12\x10\n" +
+	"\x03utc\x18\x01 \x01(\tR\x03utc\x12\x16\n" +
+	"\x06satsys\x18\x02 \x01(\tR\x06satsys\x12\x19\n" +
+	"\bsys_time\x18\x03 \x01(\tR\asysTime\x12\x10\n" +
+	"\x03tai\x18\x04 \x01(\tR\x03tai\x12\x12\n" +
+	"\x04week\x18\x05 \x01(\x05R\x04week\x12\x10\n" +
+	"\x03dow\x18\x06 \x01(\x05R\x03dow\x12\x10\n" +
+	"\x03sow\x18\a \x01(\x01R\x03sow\x12\x12\n" +
+	"\x04year\x18\b \x01(\x05R\x04year\x12\x10\n" +
+	"\x03doy\x18\t \x01(\x05R\x03doy\x12\x10\n" +
+	"\x03sod\x18\n" +
+	" \x01(\x01R\x03sod\x12\x10\n" +
+	"\x03mjd\x18\v \x01(\x05R\x03mjd\x12\x12\n" +
+	"\x04unix\x18\f \x01(\x01R\x04unix\"\x88\x01\n" +
+	"\bWeekInfo\x12\x16\n" +
+	"\x06satsys\x18\x01 \x01(\tR\x06satsys\x12\x12\n" +
+	"\x04week\x18\x02 \x01(\x05R\x04week\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\tR\x03end\x12(\n" +
+	"\x04days\x18\x05 \x03(\v2\x14.gnsscal.v1.TimeInfoR\x04days\"T\n" +
+	"\x0eConvertRequest\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\tR\x05epoch\x12\x16\n" +
+	"\x06satsys\x18\x02 \x01(\tR\x06satsys\x12\x14\n" +
+	"\x05parse\x18\x03 \x01(\tR\x05parse\"9\n" +
+	"\vDateRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x16\n" +
+	"\x06satsys\x18\x02 \x01(\tR\x06satsys\"9\n" +
+	"\vWeekRequest\x12\x12\n" +
+	"\x04week\x18\x01 \x01(\x05R\x04week\x12\x16\n" +
+	"\x06satsys\x18\x02 \x01(\tR\x06satsys\"\x7f\n" +
+	"\x0fCalendarRequest\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x16\n" +
+	"\x06satsys\x18\x03 \x01(\tR\x06satsys\x12\x16\n" +
+	"\x06layout\x18\x04 \x01(\tR\x06layout\x12\x12\n" +
+	"\x04cell\x18\x05 \x01(\tR\x04cell\"P\n" +
+	"\x10CalendarResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12(\n" +
+	"\x04days\x18\x02 \x03(\v2\x14.gnsscal.v1.TimeInfoR\x04daysB*Z(github.com/satoshi-pes/gnsscal/gnsscalpbb\x06proto3"
+
+var (
+	file_gnsscal_v1_gnsscal_proto_rawDescOnce sync.Once
+	file_gnsscal_v1_gnsscal_proto_rawDescData []byte
+)
+
+func file_gnsscal_v1_gnsscal_proto_rawDescGZIP() []byte {
+	file_gnsscal_v1_gnsscal_proto_rawDescOnce.Do(func() {
+		file_gnsscal_v1_gnsscal_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gnsscal_v1_gnsscal_proto_rawDesc), len(file_gnsscal_v1_gnsscal_proto_rawDesc)))
+	})
+	return file_gnsscal_v1_gnsscal_proto_rawDescData
+}
+
+var file_gnsscal_v1_gnsscal_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_gnsscal_v1_gnsscal_proto_goTypes = []any{
+	(*TimeInfo)(nil),         // 0: gnsscal.v1.TimeInfo
+	(*WeekInfo)(nil),         // 1: gnsscal.v1.WeekInfo
+	(*ConvertRequest)(nil),   // 2: gnsscal.v1.ConvertRequest
+	(*DateRequest)(nil),      // 3: gnsscal.v1.DateRequest
+	(*WeekRequest)(nil),      // 4: gnsscal.v1.WeekRequest
+	(*CalendarRequest)(nil),  // 5: gnsscal.v1.CalendarRequest
+	(*CalendarResponse)(nil), // 6: gnsscal.v1.CalendarResponse
+}
+var file_gnsscal_v1_gnsscal_proto_depIdxs = []int32{
+	0, // 0: gnsscal.v1.WeekInfo.days:type_name -> gnsscal.v1.TimeInfo
+	0, // 1: gnsscal.v1.CalendarResponse.days:type_name -> gnsscal.v1.TimeInfo
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_gnsscal_v1_gnsscal_proto_init() }
+func file_gnsscal_v1_gnsscal_proto_init() {
+	if File_gnsscal_v1_gnsscal_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gnsscal_v1_gnsscal_proto_rawDesc), len(file_gnsscal_v1_gnsscal_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gnsscal_v1_gnsscal_proto_goTypes,
+		DependencyIndexes: file_gnsscal_v1_gnsscal_proto_depIdxs,
+		MessageInfos:      file_gnsscal_v1_gnsscal_proto_msgTypes,
+	}.Build()
+	File_gnsscal_v1_gnsscal_proto = out.File
+	file_gnsscal_v1_gnsscal_proto_goTypes = nil
+	file_gnsscal_v1_gnsscal_proto_depIdxs = nil
+}
@@ -0,0 +1,6 @@
+// Package grpcpb contains the gRPC client and server stubs generated from
+// proto/gnsscal/v1/service.proto for the GnssCal service, of the messages of
+// the package gnsscalpb.
+package grpcpb
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/satoshi-pes/gnsscal --go-grpc_out=../.. --go-grpc_opt=module=github.com/satoshi-pes/gnsscal gnsscal/v1/service.proto
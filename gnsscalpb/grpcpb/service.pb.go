@@ -0,0 +1,82 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: gnsscal/v1/service.proto
+
+package grpcpb
+
+import (
+	gnsscalpb "github.com/satoshi-pes/gnsscal/gnsscalpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_gnsscal_v1_service_proto protoreflect.FileDescriptor
+
+const file_gnsscal_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x18gnsscal/v1/service.proto\x12\n" +
+	"gnsscal.v1\x1a\x18gnsscal/v1/gnsscal.proto2\xfb\x01\n" +
+	"\aGnssCal\x12;\n" +
+	"\aConvert\x12\x1a.gnsscal.v1.ConvertRequest\x1a\x14.gnsscal.v1.TimeInfo\x125\n" +
+	"\x04Date\x12\x17.gnsscal.v1.DateRequest\x1a\x14.gnsscal.v1.TimeInfo\x125\n" +
+	"\x04Week\x12\x17.gnsscal.v1.WeekRequest\x1a\x14.gnsscal.v1.WeekInfo\x12E\n" +
+	"\bCalendar\x12\x1b.gnsscal.v1.CalendarRequest\x1a\x1c.gnsscal.v1.CalendarResponseB1Z/github.com/satoshi-pes/gnsscal/gnsscalpb/grpcpbb\x06proto3"
+
+var file_gnsscal_v1_service_proto_goTypes = []any{
+	(*gnsscalpb.ConvertRequest)(nil),   // 0: gnsscal.v1.ConvertRequest
+	(*gnsscalpb.DateRequest)(nil),      // 1: gnsscal.v1.DateRequest
+	(*gnsscalpb.WeekRequest)(nil),      // 2: gnsscal.v1.WeekRequest
+	(*gnsscalpb.CalendarRequest)(nil),  // 3: gnsscal.v1.CalendarRequest
+	(*gnsscalpb.TimeInfo)(nil),         // 4: gnsscal.v1.TimeInfo
+	(*gnsscalpb.WeekInfo)(nil),         // 5: gnsscal.v1.WeekInfo
+	(*gnsscalpb.CalendarResponse)(nil), // 6: gnsscal.v1.CalendarResponse
+}
+var file_gnsscal_v1_service_proto_depIdxs = []int32{
+	0, // 0: gnsscal.v1.GnssCal.Convert:input_type -> gnsscal.v1.ConvertRequest
+	1, // 1: gnsscal.v1.GnssCal.Date:input_type -> gnsscal.v1.DateRequest
+	2, // 2: gnsscal.v1.GnssCal.Week:input_type -> gnsscal.v1.WeekRequest
+	3, // 3: gnsscal.v1.GnssCal.Calendar:input_type -> gnsscal.v1.CalendarRequest
+	4, // 4: gnsscal.v1.GnssCal.Convert:output_type -> gnsscal.v1.TimeInfo
+	4, // 5: gnsscal.v1.GnssCal.Date:output_type -> gnsscal.v1.TimeInfo
+	5, // 6: gnsscal.v1.GnssCal.Week:output_type -> gnsscal.v1.WeekInfo
+	6, // 7: gnsscal.v1.GnssCal.Calendar:output_type -> gnsscal.v1.CalendarResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_gnsscal_v1_service_proto_init() }
+func file_gnsscal_v1_service_proto_init() {
+	if File_gnsscal_v1_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gnsscal_v1_service_proto_rawDesc), len(file_gnsscal_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gnsscal_v1_service_proto_goTypes,
+		DependencyIndexes: file_gnsscal_v1_service_proto_depIdxs,
+	}.Build()
+	File_gnsscal_v1_service_proto = out.File
+	file_gnsscal_v1_service_proto_goTypes = nil
+	file_gnsscal_v1_service_proto_depIdxs = nil
+}
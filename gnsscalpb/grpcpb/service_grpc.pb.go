@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: gnsscal/v1/service.proto
+
+package grpcpb
+
+import (
+	context "context"
+	gnsscalpb "github.com/satoshi-pes/gnsscal/gnsscalpb"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GnssCal_Convert_FullMethodName  = "/gnsscal.v1.GnssCal/Convert"
+	GnssCal_Date_FullMethodName     = "/gnsscal.v1.GnssCal/Date"
+	GnssCal_Week_FullMethodName     = "/gnsscal.v1.GnssCal/Week"
+	GnssCal_Calendar_FullMethodName = "/gnsscal.v1.GnssCal/Calendar"
+)
+
+// GnssCalClient is the client API for GnssCal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GnssCalClient interface {
+	Convert(ctx context.Context, in *gnsscalpb.ConvertRequest, opts ...grpc.CallOption) (*gnsscalpb.TimeInfo, error)
+	Date(ctx context.Context, in *gnsscalpb.DateRequest, opts ...grpc.CallOption) (*gnsscalpb.TimeInfo, error)
+	Week(ctx context.Context, in *gnsscalpb.WeekRequest, opts ...grpc.CallOption) (*gnsscalpb.WeekInfo, error)
+	Calendar(ctx context.Context, in *gnsscalpb.CalendarRequest, opts ...grpc.CallOption) (*gnsscalpb.CalendarResponse, error)
+}
+
+type gnssCalClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGnssCalClient(cc grpc.ClientConnInterface) GnssCalClient {
+	return &gnssCalClient{cc}
+}
+
+func (c *gnssCalClient) Convert(ctx context.Context, in *gnsscalpb.ConvertRequest, opts ...grpc.CallOption) (*gnsscalpb.TimeInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gnsscalpb.TimeInfo)
+	err := c.cc.Invoke(ctx, GnssCal_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gnssCalClient) Date(ctx context.Context, in *gnsscalpb.DateRequest, opts ...grpc.CallOption) (*gnsscalpb.TimeInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gnsscalpb.TimeInfo)
+	err := c.cc.Invoke(ctx, GnssCal_Date_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gnssCalClient) Week(ctx context.Context, in *gnsscalpb.WeekRequest, opts ...grpc.CallOption) (*gnsscalpb.WeekInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gnsscalpb.WeekInfo)
+	err := c.cc.Invoke(ctx, GnssCal_Week_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gnssCalClient) Calendar(ctx context.Context, in *gnsscalpb.CalendarRequest, opts ...grpc.CallOption) (*gnsscalpb.CalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(gnsscalpb.CalendarResponse)
+	err := c.cc.Invoke(ctx, GnssCal_Calendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GnssCalServer is the server API for GnssCal service.
+// All implementations must embed UnimplementedGnssCalServer
+// for forward compatibility.
+type GnssCalServer interface {
+	Convert(context.Context, *gnsscalpb.ConvertRequest) (*gnsscalpb.TimeInfo, error)
+	Date(context.Context, *gnsscalpb.DateRequest) (*gnsscalpb.TimeInfo, error)
+	Week(context.Context, *gnsscalpb.WeekRequest) (*gnsscalpb.WeekInfo, error)
+	Calendar(context.Context, *gnsscalpb.CalendarRequest) (*gnsscalpb.CalendarResponse, error)
+	mustEmbedUnimplementedGnssCalServer()
+}
+
+// UnimplementedGnssCalServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGnssCalServer struct{}
+
+func (UnimplementedGnssCalServer) Convert(context.Context, *gnsscalpb.ConvertRequest) (*gnsscalpb.TimeInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedGnssCalServer) Date(context.Context, *gnsscalpb.DateRequest) (*gnsscalpb.TimeInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method Date not implemented")
+}
+func (UnimplementedGnssCalServer) Week(context.Context, *gnsscalpb.WeekRequest) (*gnsscalpb.WeekInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method Week not implemented")
+}
+func (UnimplementedGnssCalServer) Calendar(context.Context, *gnsscalpb.CalendarRequest) (*gnsscalpb.CalendarResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Calendar not implemented")
+}
+func (UnimplementedGnssCalServer) mustEmbedUnimplementedGnssCalServer() {}
+func (UnimplementedGnssCalServer) testEmbeddedByValue()                 {}
+
+// UnsafeGnssCalServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GnssCalServer will
+// result in compilation errors.
+type UnsafeGnssCalServer interface {
+	mustEmbedUnimplementedGnssCalServer()
+}
+
+func RegisterGnssCalServer(s grpc.ServiceRegistrar, srv GnssCalServer) {
+	// If the following call panics, it indicates UnimplementedGnssCalServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GnssCal_ServiceDesc, srv)
+}
+
+func _GnssCal_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(gnsscalpb.ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnssCalServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GnssCal_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnssCalServer).Convert(ctx, req.(*gnsscalpb.ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GnssCal_Date_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(gnsscalpb.DateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnssCalServer).Date(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GnssCal_Date_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnssCalServer).Date(ctx, req.(*gnsscalpb.DateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GnssCal_Week_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(gnsscalpb.WeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnssCalServer).Week(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GnssCal_Week_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnssCalServer).Week(ctx, req.(*gnsscalpb.WeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GnssCal_Calendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(gnsscalpb.CalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GnssCalServer).Calendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GnssCal_Calendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GnssCalServer).Calendar(ctx, req.(*gnsscalpb.CalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GnssCal_ServiceDesc is the grpc.ServiceDesc for GnssCal service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GnssCal_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gnsscal.v1.GnssCal",
+	HandlerType: (*GnssCalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Convert",
+			Handler:    _GnssCal_Convert_Handler,
+		},
+		{
+			MethodName: "Date",
+			Handler:    _GnssCal_Date_Handler,
+		},
+		{
+			MethodName: "Week",
+			Handler:    _GnssCal_Week_Handler,
+		},
+		{
+			MethodName: "Calendar",
+			Handler:    _GnssCal_Calendar_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gnsscal/v1/service.proto",
+}
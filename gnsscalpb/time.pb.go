@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: gnsscal/v1/time.proto
+
+package gnsscalpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GNSSTime struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sys           string                 `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	Week          int32                  `protobuf:"varint,2,opt,name=week,proto3" json:"week,omitempty"`
+	Sow           *durationpb.Duration   `protobuf:"bytes,3,opt,name=sow,proto3" json:"sow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GNSSTime) Reset() {
+	*x = GNSSTime{}
+	mi := &file_gnsscal_v1_time_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GNSSTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GNSSTime) ProtoMessage() {}
+
+func (x *GNSSTime) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_time_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GNSSTime.ProtoReflect.Descriptor instead.
+func (*GNSSTime) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_time_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GNSSTime) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *GNSSTime) GetWeek() int32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *GNSSTime) GetSow() *durationpb.Duration {
+	if x != nil {
+		return x.Sow
+	}
+	return nil
+}
+
+type CalendarDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sys           string                 `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Doy           int32                  `protobuf:"varint,3,opt,name=doy,proto3" json:"doy,omitempty"`
+	Week          int32                  `protobuf:"varint,4,opt,name=week,proto3" json:"week,omitempty"`
+	Dow           int32                  `protobuf:"varint,5,opt,name=dow,proto3" json:"dow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarDay) Reset() {
+	*x = CalendarDay{}
+	mi := &file_gnsscal_v1_time_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarDay) ProtoMessage() {}
+
+func (x *CalendarDay) ProtoReflect() protoreflect.Message {
+	mi := &file_gnsscal_v1_time_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarDay.ProtoReflect.Descriptor instead.
+func (*CalendarDay) Descriptor() ([]byte, []int) {
+	return file_gnsscal_v1_time_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CalendarDay) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *CalendarDay) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *CalendarDay) GetDoy() int32 {
+	if x != nil {
+		return x.Doy
+	}
+	return 0
+}
+
+func (x *CalendarDay) GetWeek() int32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *CalendarDay) GetDow() int32 {
+	if x != nil {
+		return x.Dow
+	}
+	return 0
+}
+
+var File_gnsscal_v1_time_proto protoreflect.FileDescriptor
+
+const file_gnsscal_v1_time_proto_rawDesc = "" +
+	"\n" +
+	"\x15gnsscal/v1/time.proto\x12\n" +
+	"gnsscal.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"]\n" +
+	"\bGNSSTime\x12\x10\n" +
+	"\x03sys\x18\x01 \x01(\tR\x03sys\x12\x12\n" +
+	"\x04week\x18\x02 \x01(\x05R\x04week\x12+\n" +
+	"\x03sow\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03sow\"\x87\x01\n" +
+	"\vCalendarDay\x12\x10\n" +
+	"\x03sys\x18\x01 \x01(\tR\x03sys\x12.\n" +
+	"\x04date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x10\n" +
+	"\x03doy\x18\x03 \x01(\x05R\x03doy\x12\x12\n" +
+	"\x04week\x18\x04 \x01(\x05R\x04week\x12\x10\n" +
+	"\x03dow\x18\x05 \x01(\x05R\x03dowB*Z(github.com/satoshi-pes/gnsscal/gnsscalpbb\x06proto3"
+
+var (
+	file_gnsscal_v1_time_proto_rawDescOnce sync.Once
+	file_gnsscal_v1_time_proto_rawDescData []byte
+)
+
+func file_gnsscal_v1_time_proto_rawDescGZIP() []byte {
+	file_gnsscal_v1_time_proto_rawDescOnce.Do(func() {
+		file_gnsscal_v1_time_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gnsscal_v1_time_proto_rawDesc), len(file_gnsscal_v1_time_proto_rawDesc)))
+	})
+	return file_gnsscal_v1_time_proto_rawDescData
+}
+
+var file_gnsscal_v1_time_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_gnsscal_v1_time_proto_goTypes = []any{
+	(*GNSSTime)(nil),              // 0: gnsscal.v1.GNSSTime
+	(*CalendarDay)(nil),           // 1: gnsscal.v1.CalendarDay
+	(*durationpb.Duration)(nil),   // 2: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_gnsscal_v1_time_proto_depIdxs = []int32{
+	2, // 0: gnsscal.v1.GNSSTime.sow:type_name -> google.protobuf.Duration
+	3, // 1: gnsscal.v1.CalendarDay.date:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_gnsscal_v1_time_proto_init() }
+func file_gnsscal_v1_time_proto_init() {
+	if File_gnsscal_v1_time_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gnsscal_v1_time_proto_rawDesc), len(file_gnsscal_v1_time_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gnsscal_v1_time_proto_goTypes,
+		DependencyIndexes: file_gnsscal_v1_time_proto_depIdxs,
+		MessageInfos:      file_gnsscal_v1_time_proto_msgTypes,
+	}.Build()
+	File_gnsscal_v1_time_proto = out.File
+	file_gnsscal_v1_time_proto_goTypes = nil
+	file_gnsscal_v1_time_proto_depIdxs = nil
+}
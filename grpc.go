@@ -0,0 +1,173 @@
+//go:build grpc
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/satoshi-pes/gnsscal/gnsscalpb"
+	"github.com/satoshi-pes/gnsscal/gnsscalpb/grpcpb"
+)
+
+// The grpc command is built only with the build tag 'grpc', so that the
+// default binary does not depend on gRPC:
+//
+//	go build -tags grpc
+
+func init() {
+	commands["grpc"] = runGRPC
+}
+
+// runGRPC runs the gRPC server of the GnssCal service.
+func runGRPC(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("grpc", flag.ExitOnError)
+	addr := fs.String("addr", ":9090", "address to listen on")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal grpc [-addr host:port]\n\n")
+		fmt.Fprintf(fs.Output(), "Serves gnsscal.v1.GnssCal defined in proto/gnsscal/v1/service.proto\n")
+	}
+	fs.Parse(args)
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+
+	s := grpc.NewServer()
+	grpcpb.RegisterGnssCalServer(s, grpcServer{})
+
+	// an interrupt waits for the calls in progress
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+
+	log.Printf("gnsscal gRPC serving on %s", lis.Addr())
+	if err := s.Serve(lis); err != nil {
+		return err
+	}
+	<-done
+	return nil
+}
+
+// grpcServer implements grpcpb.GnssCalServer.
+type grpcServer struct {
+	grpcpb.UnimplementedGnssCalServer
+}
+
+func (grpcServer) Convert(ctx context.Context, req *gnsscalpb.ConvertRequest) (*gnsscalpb.TimeInfo, error) {
+	sys, err := lookupSatSysOrGPS(req.Satsys)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	t, err := parseEpoch(sys, req.Epoch, req.Parse)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ti, err := newTimeInfo(sys, t)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return timeInfoPB(ti), nil
+}
+
+func (grpcServer) Date(ctx context.Context, req *gnsscalpb.DateRequest) (*gnsscalpb.TimeInfo, error) {
+	sys, err := lookupSatSysOrGPS(req.Satsys)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	date, err := parseDate(req.Date)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ti, err := newTimeInfo(sys, date)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return timeInfoPB(ti), nil
+}
+
+func (grpcServer) Week(ctx context.Context, req *gnsscalpb.WeekRequest) (*gnsscalpb.WeekInfo, error) {
+	sys, err := lookupSatSysOrGPS(req.Satsys)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Week < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid week: %d", req.Week)
+	}
+	info, err := newWeekInfo(sys, int(req.Week))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pb := &gnsscalpb.WeekInfo{
+		Satsys: string(info.SatSys),
+		Week:   int32(info.Week),
+		Start:  info.Start,
+		End:    info.End,
+	}
+	for _, ti := range info.Days {
+		pb.Days = append(pb.Days, timeInfoPB(ti))
+	}
+	return pb, nil
+}
+
+func (grpcServer) Calendar(ctx context.Context, req *gnsscalpb.CalendarRequest) (*gnsscalpb.CalendarResponse, error) {
+	path := "/" + strconv.Itoa(int(req.Year))
+	if req.Month != 0 {
+		path += "/" + strconv.Itoa(int(req.Month))
+	}
+	q := url.Values{}
+	for k, v := range map[string]string{"satsys": req.Satsys, "layout": req.Layout, "cell": req.Cell} {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	cal, err := calFromQuery(path, q)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cal.Highlight = false
+
+	sys, _ := lookupSatSys(string(cal.SatSys))
+	resp := &gnsscalpb.CalendarResponse{Text: cal.String()}
+	first, last := cal.Period()
+	for date := first; date.Before(last); date = date.Add(oneDay) {
+		ti, err := newTimeInfo(sys, date)
+		if err != nil {
+			continue // before the epoch of the system
+		}
+		resp.Days = append(resp.Days, timeInfoPB(ti))
+	}
+	return resp, nil
+}
+
+// timeInfoPB converts timeInfo to the protobuf message.
+func timeInfoPB(ti timeInfo) *gnsscalpb.TimeInfo {
+	return &gnsscalpb.TimeInfo{
+		Utc:     ti.UTC,
+		Satsys:  string(ti.SatSys),
+		SysTime: ti.SysTime,
+		Tai:     ti.TAI,
+		Week:    int32(ti.Week),
+		Dow:     int32(ti.Dow),
+		Sow:     ti.Sow,
+		Year:    int32(ti.Year),
+		Doy:     int32(ti.Doy),
+		Sod:     ti.Sod,
+		Mjd:     int32(ti.MJD),
+		Unix:    ti.Unix,
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// writeICS writes an iCalendar (RFC 5545) of the GNSS weeks of year, one
+// all-day event per week.
+func writeICS(w io.Writer, sys gnss.SystemInfo, year int, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//gnsscal//GNSS calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		fmt.Sprintf("X-WR-CALNAME:%s weeks %d", sys.Name, year),
+		"X-PUBLISHED-TTL:P1D",
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, r := range weeksOfYear(sys, year) {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%s-week-%d-%s@gnsscal", strings.ToLower(string(sys.Name)), r.Week, r.Start.Format("20060102")),
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+r.Start.Format("20060102"),
+			"DTEND;VALUE=DATE:"+r.End.Add(oneDay).Format("20060102"),
+			fmt.Sprintf("SUMMARY:%s week %d", sys.Name, r.Week),
+			fmt.Sprintf("DESCRIPTION:%s week %d\\, DOY %03d-%03d", sys.Name, r.Week, doy(r.Start), doy(r.End)),
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\r\n")+"\r\n")
+	return err
+}
+
+// handleICS serves the iCalendar of the path /ics/YYYY.ics, with the
+// satellite system given by the satsys query parameter.
+func handleICS(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/ics/"), ".ics")
+	year, err := strconv.Atoi(name)
+	if err != nil || year < 1980 || year > 9999 {
+		http.Error(w, "invalid year: "+name, http.StatusBadRequest)
+		return
+	}
+	sys, err := lookupSatSysOrGPS(r.URL.Query().Get("satsys"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%s-weeks-%d.ics", strings.ToLower(string(sys.Name)), year))
+	writeICS(w, sys, year, time.Now())
+}
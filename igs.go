@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runIGS prints the legacy IGS product filenames of a date.
+func runIGS(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("igs", flag.ExitOnError)
+	ac := fs.String("ac", "igs", "analysis center, e.g. 'igs', 'igr', 'igu', 'cod', or 'esa'")
+	ext := fs.String("ext", "", "extension of the product, e.g. 'sp3'; the common products are shown if not given")
+	weekly := fs.Bool("weekly", false, "weekly product named with day of week 7")
+	long := fs.Bool("long", false, "long product filenames, e.g. IGS0OPSFIN_20243350000_01D_15M_ORB.SP3.gz")
+	campaign := fs.String("campaign", "OPS", "project or campaign of long filenames, e.g. 'OPS' or 'MGX'")
+	solution := fs.String("solution", "FIN", "solution type of long filenames, e.g. 'FIN', 'RAP', or 'ULT'")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal igs [-ac ac] [-ext ext [-weekly]] [yyyy-mm-dd]\n")
+		fmt.Fprintf(fs.Output(), "  gnsscal igs -long [-ac AC] [-campaign CMP] [-solution SOL] [yyyy-mm-dd]\n")
+	}
+	fs.Parse(args)
+
+	date := time.Now().UTC().Truncate(oneDay)
+	if fs.NArg() > 0 {
+		var err error
+		if date, err = time.Parse("2006-01-02", fs.Arg(0)); err != nil {
+			return fmt.Errorf("invalid date: %s, error: %v", fs.Arg(0), err)
+		}
+	}
+
+	if *long {
+		ac := strings.ToUpper(*ac)
+		for _, p := range []struct{ period, sampling, content, format string }{
+			{"01D", "15M", "ORB", "SP3"},
+			{"01D", "30S", "CLK", "CLK"},
+			{"01D", "01D", "ERP", "ERP"},
+		} {
+			name, err := gnss.BuildIGSProductName(gnss.IGSProductName{
+				AC:          ac,
+				Campaign:    *campaign,
+				Solution:    *solution,
+				Start:       date,
+				Period:      p.period,
+				Sampling:    p.sampling,
+				Content:     p.content,
+				Format:      p.format,
+				Compression: "gz",
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	type product struct {
+		ext    string
+		weekly bool
+	}
+	products := []product{{"sp3", false}, {"clk", false}, {"clk_30s", false}, {"erp", true}, {"sum", true}}
+	if *ext != "" {
+		products = []product{{*ext, *weekly}}
+	}
+
+	for _, p := range products {
+		var name string
+		var err error
+		if p.weekly {
+			name, err = gnss.BuildIGSLegacyWeeklyName(*ac, date, p.ext)
+		} else {
+			name, err = gnss.BuildIGSLegacyName(*ac, date, p.ext)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println(name)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runLatency prints when the IGS products covering a date become available.
+func runLatency(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("latency", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal latency [yyyy-mm-dd]\n")
+	}
+	fs.Parse(args)
+
+	now := time.Now().UTC()
+	date := now.Truncate(oneDay)
+	if fs.NArg() > 0 {
+		var err error
+		if date, err = time.Parse("2006-01-02", fs.Arg(0)); err != nil {
+			return fmt.Errorf("invalid date: %s, error: %v", fs.Arg(0), err)
+		}
+	}
+
+	fmt.Printf("IGS products covering %s (DOY %03d)\n", date.Format("2006-01-02"), doy(date))
+	fmt.Printf("%-13s%-19s%s\n", "Tier", "Available (UTC)", "Status")
+	for _, tier := range gnss.ProductTiers {
+		t, err := gnss.ProductAvailability(tier, date)
+		if err != nil {
+			return err
+		}
+
+		status := "available"
+		if t.After(now) {
+			d := t.Sub(now).Truncate(time.Hour)
+			status = fmt.Sprintf("in %dd %02dh", int(d/oneDay), int(d%oneDay/time.Hour))
+		}
+		fmt.Printf("%-13s%-19s%s\n", tier, t.Format("2006-01-02 15:04"), status)
+	}
+
+	return nil
+}
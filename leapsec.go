@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// leapSecondEntry is a row of the leapsec command.
+type leapSecondEntry struct {
+	Date   string `json:"date"`
+	Doy    int    `json:"doy"`
+	Week   *int   `json:"gps_week"`
+	Dow    *int   `json:"gps_dow"`
+	TAIUTC int    `json:"tai_utc"`
+	GPSUTC *int   `json:"gps_utc"` // nil before GPST0
+}
+
+// runLeapSec prints all leap seconds with the GPS week and the offsets.
+func runLeapSec(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("leapsec", flag.ExitOnError)
+	format := fs.String("format", "text", "output format; 'text' or 'json'")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal leapsec [-format text|json]\n")
+	}
+	fs.Parse(args)
+
+	entries := leapSecondEntries()
+
+	switch *format {
+	case "text":
+		fmt.Printf("%-12s%-5s%6s%5s%9s%9s\n", "Date", "DOY", "Week", "Dow", "TAI-UTC", "GPS-UTC")
+		for _, e := range entries {
+			week, dow, gpsutc := "", "", ""
+			if e.Week != nil {
+				week, dow, gpsutc = strconv.Itoa(*e.Week), strconv.Itoa(*e.Dow), strconv.Itoa(*e.GPSUTC)
+			}
+			fmt.Printf("%-12s%03d  %6s%5s%9d%9s\n", e.Date, e.Doy, week, dow, e.TAIUTC, gpsutc)
+		}
+		fmt.Printf("\nleap seconds are inserted at 23:59:60 UTC of the date; table valid until %s\n",
+			gnss.LeapSecondsExpire().Format("2006-01-02"))
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	default:
+		return fmt.Errorf("invalid format: '%s'. valid formats: text, json", *format)
+	}
+
+	return nil
+}
+
+// leapSecondEntries returns the rows of all leap seconds.
+func leapSecondEntries() []leapSecondEntry {
+	var entries []leapSecondEntry
+	for _, l := range gnss.LeapSeconds() {
+		e := leapSecondEntry{
+			Date:   l.Date.Format("2006-01-02"),
+			Doy:    doy(l.Date),
+			TAIUTC: int(l.TAIMinusUTC.Seconds()),
+		}
+		if !l.Date.Before(gnss.GPST0) {
+			// GPS time is defined from GPST0 on, 19 s behind TAI
+			week, dow := gnss.WeekNumber(l.Date, gnss.GPST0), gnss.DayOfWeek(l.Date, gnss.GPST0)
+			gpsutc := e.TAIUTC - 19
+			e.Week, e.Dow, e.GPSUTC = &week, &dow, &gpsutc
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestLeapSecondEntries(t *testing.T) {
+	entries := leapSecondEntries()
+	if len(entries) == 0 {
+		t.Fatal("no leap seconds")
+	}
+	for _, e := range entries {
+		if e.Date < "1980-01-06" {
+			// GPS time is not defined before GPST0
+			if e.Week != nil || e.Dow != nil || e.GPSUTC != nil {
+				t.Errorf("%s: GPS week, dow and GPS-UTC are given before GPST0", e.Date)
+			}
+			continue
+		}
+		if e.Week == nil || e.Dow == nil || e.GPSUTC == nil {
+			t.Errorf("%s: GPS week, dow or GPS-UTC is not given", e.Date)
+			continue
+		}
+		if *e.GPSUTC != e.TAIUTC-19 {
+			t.Errorf("%s: GPS-UTC = %d, want TAI-UTC %d - 19", e.Date, *e.GPSUTC, e.TAIUTC)
+		}
+	}
+
+	first, last := entries[0], entries[len(entries)-1]
+	if first.Date != "1972-06-30" || first.TAIUTC != 11 {
+		t.Errorf("first = %s %d, want 1972-06-30 11", first.Date, first.TAIUTC)
+	}
+	if last.Date != "2016-12-31" || last.TAIUTC != 37 || *last.GPSUTC != 18 || *last.Week != 1929 || *last.Dow != 6 {
+		t.Errorf("last = %s %d %d week %d dow %d, want 2016-12-31 37 18 week 1929 dow 6",
+			last.Date, last.TAIUTC, *last.GPSUTC, *last.Week, *last.Dow)
+	}
+}
@@ -0,0 +1,173 @@
+//go:build !js
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/cache"
+	"github.com/satoshi-pes/gnsscal/calendar"
+	"github.com/satoshi-pes/gnsscal/gnss"
+	"github.com/satoshi-pes/gnsscal/gnss/iers"
+	"github.com/satoshi-pes/gnsscal/gnss/spaceweather"
+)
+
+// setVerbose prints the diagnostics of the command and the libraries to
+// stderr.
+func setVerbose() {
+	logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	gnss.SetLogger(logger)
+	iers.SetLogger(logger)
+	cache.SetLogger(logger)
+	spaceweather.SetLogger(logger)
+}
+
+func main() {
+	args := os.Args[1:]
+
+	// diagnostics, given before the command
+	if len(args) > 0 && (args[0] == "-v" || args[0] == "-debug" || args[0] == "--debug") {
+		setVerbose()
+		args = args[1:]
+	}
+
+	// interrupts cancel the downloads and shut down the servers; a second
+	// interrupt kills the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	// commands
+	if len(args) > 0 {
+		if run, ok := commands[args[0]]; ok {
+			if err := run(ctx, args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	cal, f, err := getCalWithOpt(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if f.watch {
+		err = watchCalendar(ctx, args)
+	} else {
+		err = printCalendar(ctx, os.Stdout, cal, f)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printCalendar prints cal with the footnotes of the flags f.
+func printCalendar(ctx context.Context, out io.Writer, cal *calendar.Calendar, f *calFlags) error {
+	if f.kp > 0 || f.f107 > 0 {
+		ind, warns, err := loadSpaceWeather(ctx, f.refresh)
+		for _, warn := range warns {
+			fmt.Fprintf(os.Stderr, "%v\n", warn)
+		}
+		if err != nil {
+			return err
+		}
+		first, last := cal.Period()
+		cal.SpaceWeather = activeDays(ind, first, last, f.kp, f.f107)
+	}
+
+	// print gnss calendar, streamed for long ranges of years
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+	cal.WriteTo(w)
+	w.WriteString("\n")
+
+	if f.progress {
+		g, err := gnss.TimeOf(cal.SatSys, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\n%s\n", weekProgress(g))
+	}
+
+	if cal.Leap {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(cal.LeapFootnote(), "\n"))
+	}
+
+	if cal.Events != nil {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(cal.EventFootnote(), "\n"))
+	}
+
+	if cal.Eclipse {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(cal.EclipseFootnote(), "\n"))
+	}
+
+	if cal.SpaceWeather != nil {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(cal.SpaceWeatherFootnote(), "\n"))
+	}
+
+	if cal.Moscow {
+		fmt.Fprintf(w, "\ndays in Moscow time (UTC+3); each day starts at 21:00 UTC of the day before\n")
+	}
+
+	if f.checkLeap {
+		ls, expire, warn := loadLeapSeconds(ctx, f.refresh)
+		if warn != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", warn)
+		}
+		fmt.Fprintf(w, "\n%s\n", strings.Join(checkLeapFootnote(cal, ls, expire), "\n"))
+	}
+
+	return w.Flush()
+}
+
+// watchCalendar clears the screen and prints the calendar of args again
+// when the day changes, so that today stays highlighted, until ctx is
+// done. With -p, the calendar is printed every minute to update the
+// progress of the week.
+func watchCalendar(ctx context.Context, args []string) error {
+	for {
+		cal, f, err := getCalWithOpt(args)
+		if err != nil {
+			return err
+		}
+		fmt.Print("\033[H\033[2J") // clear the screen
+		if err := printCalendar(ctx, os.Stdout, cal, f); err != nil {
+			return err
+		}
+
+		// the next day starts at 00:00 UTC, or 21:00 UTC in Moscow time
+		next := cal.Today.Add(oneDay)
+		if cal.Moscow {
+			next = next.Add(-3 * time.Hour)
+		}
+		if f.progress {
+			next = time.Now().Truncate(time.Minute).Add(time.Minute)
+		}
+		logger.Debug("watching", "next", next)
+
+		// wake up every minute in case the clock jumps, e.g. after suspend
+		for now := time.Now(); now.Before(next); now = time.Now() {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(min(next.Sub(now), time.Minute)):
+			}
+		}
+	}
+}
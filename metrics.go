@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// metric is a gauge of the Prometheus text exposition format.
+type metric struct {
+	name, help string
+	value      func(sys gnss.SystemInfo, g gnss.GNSSTime, t time.Time) float64
+}
+
+// metrics are exposed for each registered satellite system.
+var metrics = []metric{
+	{"gnsscal_week", "Current GNSS week.",
+		func(sys gnss.SystemInfo, g gnss.GNSSTime, t time.Time) float64 { return float64(g.Week) }},
+	{"gnsscal_dow", "Current GNSS day of week (0 for Sunday).",
+		func(sys gnss.SystemInfo, g gnss.GNSSTime, t time.Time) float64 { return float64(g.Dow()) }},
+	{"gnsscal_sow_seconds", "Current seconds of GNSS week.",
+		func(sys gnss.SystemInfo, g gnss.GNSSTime, t time.Time) float64 { return g.Seconds() }},
+	{"gnsscal_utc_offset_seconds", "Offset of the system time from UTC, e.g. GPS-UTC.",
+		func(sys gnss.SystemInfo, g gnss.GNSSTime, t time.Time) float64 { return sys.UTCOffset(t).Seconds() }},
+}
+
+// writeMetrics writes the metrics at t in the Prometheus text format.
+func writeMetrics(w io.Writer, t time.Time) error {
+	t = t.UTC()
+	for _, m := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", m.name, m.help, m.name)
+		for _, sys := range gnss.Systems() {
+			g, err := gnss.TimeOf(sys.Name, t)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "%s{satsys=\"%s\"} %g\n", m.name, sys.Name, m.value(sys, g, t))
+		}
+	}
+
+	fmt.Fprintf(w, "# HELP gnsscal_doy Current day of year (UTC).\n# TYPE gnsscal_doy gauge\n")
+	fmt.Fprintf(w, "gnsscal_doy %d\n", t.YearDay())
+	fmt.Fprintf(w, "# HELP gnsscal_tai_utc_seconds Current TAI-UTC.\n# TYPE gnsscal_tai_utc_seconds gauge\n")
+	_, err := fmt.Fprintf(w, "gnsscal_tai_utc_seconds %g\n", gnss.TAIMinusUTC(t).Seconds())
+	return err
+}
+
+// handleMetrics serves the metrics of the current time.
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeMetrics(w, time.Now())
+}
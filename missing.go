@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runMissing prints the days of a date range missing RINEX files in a data
+// directory per station, with the doy and GNSS week/dow of the days.
+func runMissing(ctx context.Context, args []string) error {
+	fset := flag.NewFlagSet("missing", flag.ExitOnError)
+	satsys := fset.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	typ := fset.String("type", "", "file type of the files counted, e.g. 'o' or 'MO'; all types if empty")
+	format := fset.String("format", "text", "output format; 'text' or 'csv'")
+	dates := fset.String("dates", "calendar", "notation of dates; 'calendar' (2024-05-02), 'ordinal' (2024-123), or 'week' (2024-W18-4)")
+	fset.Usage = func() {
+		fmt.Fprintf(fset.Output(), "Usage:\n  gnsscal missing [-satsys sys] [-type t] [-format text|csv] [-dates style] dir from to [station...]\n\n")
+		fmt.Fprintf(fset.Output(), "The directory is scanned recursively for RINEX v2 and v3/v4 filenames, and the days\n")
+		fmt.Fprintf(fset.Output(), "from and to (inclusive, yyyy-mm-dd or yyyy:ddd) without a file are listed per\n")
+		fmt.Fprintf(fset.Output(), "station; of the stations given, or else of all stations found. Stations are\n")
+		fmt.Fprintf(fset.Output(), "matched by the 4-character names, the first characters of RINEX 3 names, in any case.\n")
+	}
+	fset.Parse(args)
+
+	if fset.NArg() < 3 {
+		fset.Usage()
+		return fmt.Errorf("directory and date range are not given")
+	}
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+	ds, err := parseDateStyle(*dates)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "text", "csv":
+	default:
+		return fmt.Errorf("invalid format: '%s'. valid formats: text, csv", *format)
+	}
+
+	from, err := parseDate(fset.Arg(1))
+	if err != nil {
+		return err
+	}
+	to, err := parseDate(fset.Arg(2))
+	if err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return fmt.Errorf("invalid date range: %s is before %s", fset.Arg(2), fset.Arg(1))
+	}
+
+	found, err := scanRinexDays(ctx, fset.Arg(0), *typ)
+	if err != nil {
+		return err
+	}
+
+	var stations []string
+	for _, s := range fset.Args()[3:] {
+		stations = append(stations, stationID(s))
+	}
+	if len(stations) == 0 {
+		for s := range found {
+			stations = append(stations, s)
+		}
+		slices.Sort(stations)
+	}
+	if len(stations) == 0 {
+		return fmt.Errorf("no RINEX files in %s", fset.Arg(0))
+	}
+
+	// rows of the missing days, and the numbers of them per station
+	rows := [][]string{{"Date", "DOY", "Week", "Dow", "Station"}}
+	missing := make(map[string]int)
+	for _, station := range stations {
+		for date := from; !date.After(to); date = date.Add(oneDay) {
+			if found[station][date] {
+				continue
+			}
+			week, dow := "", ""
+			if epoch := sys.EpochAt(date); !date.Before(epoch) {
+				week = strconv.Itoa(gnss.WeekNumber(date, epoch))
+				dow = strconv.Itoa(gnss.DayOfWeek(date, epoch))
+			}
+			rows = append(rows, []string{ds.date(date), fmt.Sprintf("%03d", doy(date)), week, dow, station})
+			missing[station]++
+		}
+	}
+
+	if *format == "csv" {
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+
+	if len(rows) > 1 {
+		printColumns(rows)
+		fmt.Println()
+	}
+	days := int(to.Sub(from)/oneDay) + 1
+	for _, station := range stations {
+		fmt.Printf("%-4s  %d of %d days missing\n", station, missing[station], days)
+	}
+	return nil
+}
+
+// scanRinexDays returns the days of the RINEX files under dir of the file
+// type typ (all types if empty) per station. Files of other names are
+// skipped.
+func scanRinexDays(ctx context.Context, dir, typ string) (map[string]map[time.Time]bool, error) {
+	found := make(map[string]map[time.Time]bool)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := gnss.ParseRinexName(path)
+		if err != nil {
+			logger.Debug("skipping file", "path", path, "error", err)
+			return nil
+		}
+		if typ != "" && !strings.EqualFold(info.Type, typ) {
+			return nil
+		}
+		station := stationID(info.Station)
+		if found[station] == nil {
+			found[station] = make(map[time.Time]bool)
+		}
+		found[station][info.Date.Truncate(oneDay)] = true
+		return nil
+	})
+	return found, err
+}
+
+// stationID returns the 4-character name of a station in lower case, e.g.
+// "tskb" of "TSKB00JPN".
+func stationID(name string) string {
+	if len(name) > 4 {
+		name = name[:4]
+	}
+	return strings.ToLower(name)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runNow prints the current GNSS time.
+func runNow(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("now", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	moscow := fs.Bool("moscow", false, "shows doy and seconds of day in Moscow time (UTC+3) for GLO")
+	badge := fs.Bool("badge", false, "prints an SVG badge of the GNSS week and doy instead")
+	oneline := fs.Bool("oneline", false, "prints a single line of -template instead, e.g. for status bars")
+	tmpl := fs.String("template", onelineTemplate, "layout of -oneline with the verbs of query -format")
+	fs.Parse(args)
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+
+	loc, err := dayBoundary(sys, *moscow)
+	if err != nil {
+		return err
+	}
+
+	if *badge {
+		return writeBadge(os.Stdout, sys, time.Now())
+	}
+
+	// a template other than the default implies -oneline
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "template" {
+			*oneline = true
+		}
+	})
+	if *oneline {
+		if *moscow {
+			return fmt.Errorf("-moscow cannot be used with -oneline; the verbs of the template are of UTC")
+		}
+		s, err := gnss.Format(*tmpl, sys.Name, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Println(s)
+		return nil
+	}
+
+	return printTimeInfo(os.Stdout, sys, time.Now().UTC().Truncate(time.Second), loc, dateCalendar)
+}
+
+// onelineTemplate is the default template of now -oneline, e.g.
+// "W2313 D4 DOY124".
+const onelineTemplate = "W%W D%N DOY%j"
+
+// weekProgress returns a line showing the day of week, the seconds of week,
+// and the percentage of the week elapsed.
+func weekProgress(g gnss.GNSSTime) string {
+	return fmt.Sprintf("%s week %d, day %d, %6.0f s of week, %5.1f%% elapsed",
+		g.Sys, g.Week, g.Dow(), g.Seconds(), g.Progress())
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// timeScale is a time scale shown by the offsets command.
+type timeScale struct {
+	name   string
+	fromFn func(t time.Time) time.Time // calendar time of the scale at t (UTC)
+}
+
+// timeScales are the time scales of the offsets command.
+// GST is steered to GPS time and treated as GPS time.
+var timeScales = []timeScale{
+	{"UTC", func(t time.Time) time.Time { return t }},
+	{"TAI", gnss.UTCToTAI},
+	{"GPST", gnss.UTCToGPST},
+	{"GST", gnss.UTCToGPST},
+	{"BDT", gnss.UTCToBDT},
+	{"GLONASST", func(t time.Time) time.Time { return t.Add(3 * time.Hour) }},
+}
+
+// runOffsets prints the offsets between time scales at a date.
+func runOffsets(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("offsets", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal offsets [yyyy-mm-dd]\n")
+	}
+	fs.Parse(args)
+
+	date := time.Now().UTC().Truncate(oneDay)
+	if fs.NArg() > 0 {
+		var err error
+		if date, err = time.Parse("2006-01-02", fs.Arg(0)); err != nil {
+			return fmt.Errorf("invalid date: %s, error: %v", fs.Arg(0), err)
+		}
+	}
+
+	fmt.Printf("Offsets of time scales on %s (row - column, in seconds)\n", date.Format("2006-01-02"))
+	fmt.Printf("%-10s", "")
+	for _, col := range timeScales {
+		fmt.Printf("%10s", col.name)
+	}
+	fmt.Println()
+	for _, row := range timeScales {
+		fmt.Printf("%-10s", row.name)
+		for _, col := range timeScales {
+			d := row.fromFn(date).Sub(col.fromFn(date))
+			fmt.Printf("%10s", fmt.Sprintf("%+d", int(d/time.Second)))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("\nTAI-UTC %d s; GPST, GST, and BDT have no leap seconds; GLONASST is UTC+3h\n",
+		int(gnss.TAIMinusUTC(date)/time.Second))
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runOrganize moves or symlinks GNSS data files into the YYYY/DDD or GPS
+// week directory tree by the dates parsed from their filenames.
+func runOrganize(ctx context.Context, args []string) error {
+	fset := flag.NewFlagSet("organize", flag.ExitOnError)
+	tree := fset.String("tree", "doy", "directory tree; 'doy' for YYYY/DDD, or 'week' for GPS weeks (WWWW)")
+	link := fset.Bool("link", false, "creates symbolic links to the files instead of moving them")
+	dryRun := fset.Bool("n", false, "dry run; prints the moves or links without making them")
+	fset.Usage = func() {
+		fmt.Fprintf(fset.Output(), "Usage:\n  gnsscal organize [-tree doy|week] [-link] [-n] dest file|dir...\n\n")
+		fmt.Fprintf(fset.Output(), "The dates are parsed from RINEX v2 and v3/v4 filenames and IGS long product\n")
+		fmt.Fprintf(fset.Output(), "filenames; directories are scanned recursively, and other files are skipped.\n")
+		fmt.Fprintf(fset.Output(), "Files are not overwritten; those of existing destinations are reported.\n")
+	}
+	fset.Parse(args)
+
+	if fset.NArg() < 2 {
+		fset.Usage()
+		return fmt.Errorf("destination and files are not given")
+	}
+	switch *tree {
+	case "doy", "week":
+	default:
+		return fmt.Errorf("invalid tree: '%s'. valid trees: doy, week", *tree)
+	}
+	dest := fset.Arg(0)
+
+	var failed int
+	organize := func(src string) error {
+		date, ok := fileDate(src)
+		if !ok {
+			logger.Debug("skipping file", "path", src)
+			return nil
+		}
+		dir, err := treeDir(dest, *tree, date)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, filepath.Base(src))
+		if same, _ := sameFile(src, dst); same {
+			return nil // organized already
+		}
+
+		op := "mv"
+		if *link {
+			op = "ln -s"
+			if src, err = filepath.Abs(src); err != nil {
+				return err
+			}
+		}
+		if _, err := os.Lstat(dst); err == nil {
+			fmt.Fprintf(os.Stderr, "%s exists, skipping %s\n", dst, src)
+			failed++
+			return nil
+		}
+		fmt.Printf("%s %s %s\n", op, src, dst)
+		if *dryRun {
+			return nil
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		if *link {
+			return os.Symlink(src, dst)
+		}
+		return os.Rename(src, dst)
+	}
+
+	for _, arg := range fset.Args()[1:] {
+		err := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			return organize(path)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d files are not organized as the destinations exist", failed)
+	}
+	return nil
+}
+
+// fileDate returns the date of a RINEX or IGS long product filename.
+func fileDate(name string) (time.Time, bool) {
+	if info, err := gnss.ParseRinexName(name); err == nil {
+		return info.Date.Truncate(oneDay), true
+	}
+	if n, err := gnss.ParseIGSProductName(name); err == nil {
+		return n.Start.Truncate(oneDay), true
+	}
+	return time.Time{}, false
+}
+
+// treeDir returns the directory of date under root in tree; YYYY/DDD for
+// "doy", or the GPS week directory for "week".
+func treeDir(root, tree string, date time.Time) (string, error) {
+	if tree == "week" {
+		dir, err := gnss.WeekDir(root, date)
+		return filepath.FromSlash(dir), err
+	}
+	return filepath.Join(root, fmt.Sprintf("%04d", date.Year()), fmt.Sprintf("%03d", doy(date))), nil
+}
+
+// sameFile reports whether a and b are the same file.
+func sameFile(a, b string) (bool, error) {
+	fa, err := os.Lstat(a)
+	if err != nil {
+		return false, err
+	}
+	fb, err := os.Lstat(b)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return os.SameFile(fa, fb), nil
+}
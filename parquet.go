@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// parquet physical and converted types, repetitions, and encodings used by
+// writeParquet (see parquet.thrift of Apache Parquet)
+const (
+	parquetInt32     = 1
+	parquetByteArray = 6
+
+	parquetUTF8 = 0
+	parquetDate = 6
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetPlain = 0
+	parquetRLE   = 3
+)
+
+// parquetColumn is a column of the day table; the values of a row group
+// are encoded in values, and the definition levels in defs if the column
+// is optional.
+type parquetColumn struct {
+	name      string
+	typ       int32
+	converted int32 // -1 if none
+	optional  bool
+
+	values bytes.Buffer
+	defs   []bool
+	n      int
+}
+
+// addInt32 appends v to the column, or a null if ok is false.
+func (c *parquetColumn) addInt32(v int, ok bool) {
+	c.n++
+	if c.optional {
+		c.defs = append(c.defs, ok)
+	}
+	if ok {
+		binary.Write(&c.values, binary.LittleEndian, int32(v))
+	}
+}
+
+// addString appends s to the column.
+func (c *parquetColumn) addString(s string) {
+	c.n++
+	binary.Write(&c.values, binary.LittleEndian, uint32(len(s)))
+	c.values.WriteString(s)
+}
+
+// page returns the data page of the values added, and resets the column.
+func (c *parquetColumn) page() []byte {
+	var body bytes.Buffer
+	if c.optional {
+		levels := rleLevels(c.defs)
+		binary.Write(&body, binary.LittleEndian, uint32(len(levels)))
+		body.Write(levels)
+	}
+	body.Write(c.values.Bytes())
+
+	var h thriftWriter
+	h.i32(1, 0) // DATA_PAGE
+	h.i32(2, int32(body.Len()))
+	h.i32(3, int32(body.Len()))
+	h.beginStruct(5)
+	h.i32(1, int32(c.n))
+	h.i32(2, parquetPlain)
+	h.i32(3, parquetRLE)
+	h.i32(4, parquetRLE)
+	h.endStruct()
+	h.stop()
+
+	c.values.Reset()
+	c.defs, c.n = c.defs[:0], 0
+	return append(h.Bytes(), body.Bytes()...)
+}
+
+// rleLevels encodes the definition levels of bit width 1 in runs of the
+// RLE/bit-packing hybrid encoding.
+func rleLevels(defs []bool) []byte {
+	var b []byte
+	for i := 0; i < len(defs); {
+		j := i
+		for j < len(defs) && defs[j] == defs[i] {
+			j++
+		}
+		b = binary.AppendUvarint(b, uint64(j-i)<<1)
+		if defs[i] {
+			b = append(b, 1)
+		} else {
+			b = append(b, 0)
+		}
+		i = j
+	}
+	return b
+}
+
+// parquetChunk is the metadata of a column chunk written.
+type parquetChunk struct {
+	offset, size int64
+	n            int
+}
+
+// writeParquet writes the table of the days of years as an Apache Parquet
+// file, of the columns date, year, doy, weekday, and GNSS week and dow of
+// sys, which are null before the epoch of sys. A row group is written per
+// year, so that long ranges are written in bounded memory.
+func writeParquet(ctx context.Context, w io.Writer, sys gnss.SystemInfo, years []int) error {
+	cols := []*parquetColumn{
+		{name: "date", typ: parquetInt32, converted: parquetDate},
+		{name: "year", typ: parquetInt32, converted: -1},
+		{name: "doy", typ: parquetInt32, converted: -1},
+		{name: "weekday", typ: parquetByteArray, converted: parquetUTF8},
+		{name: "week", typ: parquetInt32, converted: -1, optional: true},
+		{name: "dow", typ: parquetInt32, converted: -1, optional: true},
+	}
+
+	cw := &countWriter{w: w}
+	if _, err := io.WriteString(cw, "PAR1"); err != nil {
+		return err
+	}
+
+	var groups [][]parquetChunk
+	var rows int
+	for _, year := range years {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		firstDay := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		for date := firstDay; date.Year() == year; date = date.Add(oneDay) {
+			epoch := sys.EpochAt(date)
+			valid := !date.Before(epoch)
+			cols[0].addInt32(gnss.DaysBetween(time.Unix(0, 0).UTC(), date), true)
+			cols[1].addInt32(year, true)
+			cols[2].addInt32(doy(date), true)
+			cols[3].addString(date.Weekday().String()[:3])
+			cols[4].addInt32(gnss.WeekNumber(date, epoch), valid)
+			cols[5].addInt32(gnss.DayOfWeek(date, epoch), valid)
+			rows++
+		}
+
+		var chunks []parquetChunk
+		for _, c := range cols {
+			n := c.n
+			page := c.page()
+			chunks = append(chunks, parquetChunk{cw.n, int64(len(page)), n})
+			if _, err := cw.Write(page); err != nil {
+				return err
+			}
+		}
+		groups = append(groups, chunks)
+	}
+
+	// footer of the file metadata
+	var m thriftWriter
+	m.i32(1, 1)
+	m.beginList(2, thriftStruct, len(cols)+1)
+	m.beginElem()
+	m.binary(4, "schema")
+	m.i32(5, int32(len(cols)))
+	m.endStruct()
+	for _, c := range cols {
+		m.beginElem()
+		m.i32(1, c.typ)
+		if c.optional {
+			m.i32(3, parquetOptional)
+		} else {
+			m.i32(3, parquetRequired)
+		}
+		m.binary(4, c.name)
+		if c.converted >= 0 {
+			m.i32(6, c.converted)
+		}
+		m.endStruct()
+	}
+	m.i64(3, int64(rows))
+	m.beginList(4, thriftStruct, len(groups))
+	for _, chunks := range groups {
+		var size int64
+		m.beginElem()
+		m.beginList(1, thriftStruct, len(chunks))
+		for j, ch := range chunks {
+			c := cols[j]
+			size += ch.size
+			m.beginElem()
+			m.i64(2, ch.offset)
+			m.beginStruct(3)
+			m.i32(1, c.typ)
+			m.beginList(2, thriftI32, 2)
+			m.listI32(parquetPlain)
+			m.listI32(parquetRLE)
+			m.beginList(3, thriftBinary, 1)
+			m.listBinary(c.name)
+			m.i32(4, 0) // UNCOMPRESSED
+			m.i64(5, int64(ch.n))
+			m.i64(6, ch.size)
+			m.i64(7, ch.size)
+			m.i64(9, ch.offset)
+			m.endStruct()
+			m.endStruct()
+		}
+		m.i64(2, size)
+		m.i64(3, int64(chunks[0].n))
+		m.endStruct()
+	}
+	m.binary(6, "gnsscal")
+	m.stop()
+
+	footer := m.Bytes()
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	footer = append(footer, "PAR1"...)
+	_, err := cw.Write(footer)
+	return err
+}
+
+// countWriter counts the bytes written to w, for the offsets of the file.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// types of the Thrift compact protocol
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs in the Thrift compact protocol, of which
+// the Parquet metadata are serialized.
+type thriftWriter struct {
+	bytes.Buffer
+	last  int16   // id of the last field of the current struct
+	stack []int16 // ids of the last fields of the outer structs
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	if d := id - t.last; 0 < d && d <= 15 {
+		t.WriteByte(byte(d)<<4 | typ)
+	} else {
+		t.WriteByte(typ)
+		t.varint(int64(id))
+	}
+	t.last = id
+}
+
+func (t *thriftWriter) varint(v int64) {
+	t.Write(binary.AppendVarint(nil, v)) // zigzag
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(v)
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.listBinary(s)
+}
+
+func (t *thriftWriter) beginList(id int16, typ byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.WriteByte(byte(n)<<4 | typ)
+	} else {
+		t.WriteByte(0xf0 | typ)
+		t.Write(binary.AppendUvarint(nil, uint64(n)))
+	}
+}
+
+func (t *thriftWriter) listI32(v int32) {
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) listBinary(s string) {
+	t.Write(binary.AppendUvarint(nil, uint64(len(s))))
+	t.WriteString(s)
+}
+
+func (t *thriftWriter) beginStruct(id int16) {
+	t.field(id, thriftStruct)
+	t.beginElem()
+}
+
+// beginElem begins a struct of an element of a list.
+func (t *thriftWriter) beginElem() {
+	t.stack = append(t.stack, t.last)
+	t.last = 0
+}
+
+func (t *thriftWriter) endStruct() {
+	t.stop()
+	t.last, t.stack = t.stack[len(t.stack)-1], t.stack[:len(t.stack)-1]
+}
+
+// stop ends the fields of a struct.
+func (t *thriftWriter) stop() {
+	t.WriteByte(0)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// thriftReader decodes the structs of the Thrift compact protocol into maps
+// of field ids to int64, string, []any, or map[int16]any.
+type thriftReader struct {
+	b   []byte
+	err error
+}
+
+func (r *thriftReader) byte() byte {
+	if len(r.b) == 0 {
+		r.err = fmt.Errorf("unexpected end of data")
+		return 0
+	}
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+func (r *thriftReader) varint() int64 {
+	v, n := binary.Varint(r.b)
+	if n <= 0 {
+		r.err = fmt.Errorf("invalid varint")
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.err = fmt.Errorf("invalid uvarint")
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := int(r.uvarint())
+		if n > len(r.b) {
+			r.err = fmt.Errorf("binary of %d bytes beyond the data", n)
+			return ""
+		}
+		s := string(r.b[:n])
+		r.b = r.b[n:]
+		return s
+	case thriftList:
+		h := r.byte()
+		n := int(h >> 4)
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		var list []any
+		for i := 0; i < n && r.err == nil; i++ {
+			list = append(list, r.value(h&0x0f))
+		}
+		return list
+	case thriftStruct:
+		return r.structure()
+	}
+	r.err = fmt.Errorf("unsupported type %d", typ)
+	return nil
+}
+
+func (r *thriftReader) structure() map[int16]any {
+	m := make(map[int16]any)
+	var id int16
+	for r.err == nil {
+		h := r.byte()
+		if h == 0 {
+			break
+		}
+		if d := int16(h >> 4); d != 0 {
+			id += d
+		} else {
+			id = int16(r.varint())
+		}
+		m[id] = r.value(h & 0x0f)
+	}
+	return m
+}
+
+func TestWriteParquet(t *testing.T) {
+	sys, _ := gnss.Lookup(string(gnss.SYSGAL))
+	var buf bytes.Buffer
+	if err := writeParquet(context.Background(), &buf, sys, []int{1999, 2000}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatalf("no magic numbers of parquet")
+	}
+	n := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	fr := &thriftReader{b: data[len(data)-8-n : len(data)-8]}
+	meta := fr.structure()
+	if fr.err != nil || len(fr.b) != 0 {
+		t.Fatalf("invalid footer: %v, %d bytes left", fr.err, len(fr.b))
+	}
+
+	if meta[1] != int64(1) || meta[3] != int64(366+365) || meta[6] != "gnsscal" {
+		t.Errorf("version %v, rows %v, created by %v", meta[1], meta[3], meta[6])
+	}
+
+	// schema
+	schema := meta[2].([]any)
+	if root := schema[0].(map[int16]any); root[4] != "schema" || root[5] != int64(6) {
+		t.Errorf("root of schema = %v", root)
+	}
+	want := []struct {
+		name      string
+		typ, rep  int64
+		converted any
+	}{
+		{"date", parquetInt32, parquetRequired, int64(parquetDate)},
+		{"year", parquetInt32, parquetRequired, nil},
+		{"doy", parquetInt32, parquetRequired, nil},
+		{"weekday", parquetByteArray, parquetRequired, int64(parquetUTF8)},
+		{"week", parquetInt32, parquetOptional, nil},
+		{"dow", parquetInt32, parquetOptional, nil},
+	}
+	if len(schema) != len(want)+1 {
+		t.Fatalf("%d elements of schema, want %d", len(schema), len(want)+1)
+	}
+	for i, w := range want {
+		e := schema[i+1].(map[int16]any)
+		if e[4] != w.name || e[1] != w.typ || e[3] != w.rep || e[6] != w.converted {
+			t.Errorf("schema of column %d = %v, want %+v", i, e, w)
+		}
+	}
+
+	// row groups, of which 1999 has the weeks of GAL from 1999-08-22 (doy 234)
+	groups := meta[4].([]any)
+	if len(groups) != 2 {
+		t.Fatalf("%d row groups, want 2", len(groups))
+	}
+	for g, days := range []int{365, 366} {
+		group := groups[g].(map[int16]any)
+		if group[3] != int64(days) {
+			t.Errorf("rows of group %d = %v, want %d", g, group[3], days)
+		}
+		chunks := group[1].([]any)
+		for c, chunk := range chunks {
+			cm := chunk.(map[int16]any)[3].(map[int16]any)
+			if cm[5] != int64(days) || cm[3].([]any)[0] != want[c].name {
+				t.Errorf("chunk %d of group %d = %v", c, g, cm)
+			}
+			if want[c].rep != parquetOptional {
+				continue
+			}
+			defs, values := pageDefs(t, data, cm[9].(int64))
+			nulls := 0
+			for _, d := range defs {
+				if !d {
+					nulls++
+				}
+			}
+			if wantNulls := []int{233, 0}[g]; len(defs) != days || nulls != wantNulls {
+				t.Errorf("%s of %d: %d levels with %d nulls, want %d with %d", want[c].name, 1999+g, len(defs), nulls, days, wantNulls)
+			}
+			if len(values) != 4*(days-nulls) {
+				t.Errorf("%s of %d: %d bytes of values, want %d", want[c].name, 1999+g, len(values), 4*(days-nulls))
+			}
+		}
+	}
+}
+
+// pageDefs returns the definition levels and the encoded values of the
+// data page at offset.
+func pageDefs(t *testing.T, data []byte, offset int64) (defs []bool, values []byte) {
+	t.Helper()
+	r := &thriftReader{b: data[offset:]}
+	h := r.structure()
+	if r.err != nil || h[1] != int64(0) {
+		t.Fatalf("invalid page header at %d: %v %v", offset, h, r.err)
+	}
+	body := r.b[:h[3].(int64)]
+	n := int(binary.LittleEndian.Uint32(body))
+	levels := body[4 : 4+n]
+	for len(levels) > 0 {
+		v, k := binary.Uvarint(levels)
+		if v&1 != 0 {
+			t.Fatalf("bit-packed runs are not written")
+		}
+		for i := 0; i < int(v>>1); i++ {
+			defs = append(defs, levels[k] == 1)
+		}
+		levels = levels[k+1:]
+	}
+	return defs, body[4+n:]
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runPlanner prints the planning table of a campaign: the doy and GNSS
+// week/dow of every day of a date range, with a column of the RINEX v2
+// filename per station and session.
+func runPlanner(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("planner", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	sessions := fs.String("sessions", "0", "comma-separated session letters; 'a'-'x' for hourly, '0' for daily")
+	typ := fs.String("type", "o", "RINEX v2 file type of the filenames, e.g. 'o' or 'd'")
+	format := fs.String("format", "text", "output format; 'text' or 'csv'")
+	dates := fs.String("dates", "calendar", "notation of dates; 'calendar' (2024-05-02), 'ordinal' (2024-123), or 'week' (2024-W18-4)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal planner [-satsys sys] [-sessions s,...] [-type t] [-format text|csv] [-dates style] from to [station...]\n\n")
+		fmt.Fprintf(fs.Output(), "The dates from and to (inclusive) are yyyy-mm-dd or yyyy:ddd. A column per station\n")
+		fmt.Fprintf(fs.Output(), "and session holds the RINEX v2 filename of the day, e.g. 'tsk21230.24o'; without\n")
+		fmt.Fprintf(fs.Output(), "stations, a column per session holds the doy and the session letter, e.g. '123a'.\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return fmt.Errorf("date range is not given")
+	}
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+	ds, err := parseDateStyle(*dates)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "text", "csv":
+	default:
+		return fmt.Errorf("invalid format: '%s'. valid formats: text, csv", *format)
+	}
+	if len(*typ) != 1 {
+		return fmt.Errorf("invalid file type: '%s'", *typ)
+	}
+
+	from, err := parseDate(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	to, err := parseDate(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return fmt.Errorf("invalid date range: %s is before %s", fs.Arg(1), fs.Arg(0))
+	}
+
+	var letters []byte
+	for _, s := range strings.Split(*sessions, ",") {
+		if len(s) != 1 {
+			return fmt.Errorf("invalid session letter: '%s'", s)
+		}
+		if _, err := gnss.HourFromSession(s[0]); err != nil {
+			return err
+		}
+		letters = append(letters, s[0])
+	}
+	stations := fs.Args()[2:]
+
+	// header
+	header := []string{"Date", "DOY", "Week", "Dow"}
+	for _, letter := range letters {
+		if len(stations) == 0 {
+			header = append(header, "Session "+string(letter))
+		}
+		for _, station := range stations {
+			header = append(header, fmt.Sprintf("%s %c", strings.ToLower(station), letter))
+		}
+	}
+
+	// rows
+	rows := [][]string{header}
+	for date := from; !date.After(to); date = date.Add(oneDay) {
+		week, dow := "", ""
+		if epoch := sys.EpochAt(date); !date.Before(epoch) {
+			week = strconv.Itoa(gnss.WeekNumber(date, epoch))
+			dow = strconv.Itoa(gnss.DayOfWeek(date, epoch))
+		}
+		row := []string{ds.date(date), fmt.Sprintf("%03d", doy(date)), week, dow}
+		for _, letter := range letters {
+			if len(stations) == 0 {
+				row = append(row, fmt.Sprintf("%03d%c", doy(date), letter))
+			}
+			for _, station := range stations {
+				name, err := gnss.BuildRinex2Name(station, date, letter, (*typ)[0])
+				if err != nil {
+					return err
+				}
+				row = append(row, name)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if *format == "csv" {
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+	printColumns(rows)
+	return nil
+}
+
+// printColumns prints the rows of the planner and the schedule commands in
+// columns separated by two spaces; doy, week and dow are right aligned.
+func printColumns(rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		b.Reset()
+		for i, cell := range row {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			switch {
+			case 0 < i && i < 4:
+				fmt.Fprintf(&b, "%*s", widths[i], cell) // doy, week, dow
+			case i == len(row)-1:
+				b.WriteString(cell)
+			default:
+				fmt.Fprintf(&b, "%-*s", widths[i], cell)
+			}
+		}
+		fmt.Println(b.String())
+	}
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/cache"
+	"github.com/satoshi-pes/gnsscal/gnss"
+	"github.com/satoshi-pes/gnsscal/gnss/iers"
+)
+
+// runQuery prints the GNSS time of the given epochs.
+func runQuery(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	parse := fs.String("parse", "", "layout of epochs, e.g. '%Y%j' (see Layouts)")
+	format := fs.String("format", "", "prints epochs formatted with the layout, e.g. 'igs%W%N.sp3' (see Layouts)")
+	tai := fs.Bool("tai", false, "reads calendar epochs without a time zone (yyyy:ddd, yyyy-mm-dd) in TAI instead of UTC")
+	bdt := fs.Bool("bdt", false, "reads calendar epochs without a time zone (yyyy:ddd, yyyy-mm-dd) in BDT instead of UTC")
+	finals := fs.String("finals", "", "IERS finals2000A file, or http(s) URL, to show UT1")
+	refresh := fs.Bool("refresh", false, "downloads the finals URL again ignoring the cache")
+	jsonOut := fs.Bool("json", false, "prints epochs in JSON, one object per line")
+	moscow := fs.Bool("moscow", false, "shows doy and seconds of day in Moscow time (UTC+3) for GLO")
+	unix := fs.String("unix", "", "reads epochs as Unix time in the unit; 's', 'ms', 'us', or 'ns'")
+	jobs := fs.Int("jobs", runtime.NumCPU(), "number of goroutines converting the epochs read from stdin")
+	explain := fs.Bool("explain", false, "shows the steps converting UTC to the GNSS week, e.g. the leap seconds applied")
+	dates := fs.String("dates", "calendar", "notation of dates; 'calendar' (2024-05-02), 'ordinal' (2024-123), or 'week' (2024-W18-4)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal query [-satsys sys] [-parse layout | -unix unit] [-tai | -bdt] [-finals file|url [-refresh]] [-moscow] [-dates style] [-format layout | -json | -explain] epoch... | [-jobs n] -\n\n")
+		fmt.Fprintf(fs.Output(), "Epochs:\n")
+		fmt.Fprintf(fs.Output(), "  yyyy:ddd[:sod]   year, doy, and seconds of day, e.g. 2024:123:43200\n")
+		fmt.Fprintf(fs.Output(), "                   or time of day instead of sod, e.g. 2024:123:12:00:00.5\n")
+		fmt.Fprintf(fs.Output(), "  wwww:d[:sow]     GNSS week, day of week, and seconds of week, e.g. 2300:3:259200\n")
+		fmt.Fprintf(fs.Output(), "                   or the start of the day, e.g. 2300:3\n")
+		fmt.Fprintf(fs.Output(), "  wwww:sow         GNSS week and seconds of week, e.g. 2300:259200\n")
+		fmt.Fprintf(fs.Output(), "  yyyy-mm-dd       date, e.g. 2024-05-02, or with time of day, '2024-05-02 12:00:00.5'\n")
+		fmt.Fprintf(fs.Output(), "  RFC 3339         e.g. 2024-05-02T12:00:00Z\n")
+		fmt.Fprintf(fs.Output(), "  @seconds         Unix time, e.g. @1714651200 (see also -unix)\n")
+		fmt.Fprintf(fs.Output(), "  MJDnnnnn[.f]     modified Julian date with a fraction of day, e.g. MJD60432.5\n")
+		fmt.Fprintf(fs.Output(), "  epoch record     RINEX 3 or SP3 epoch, e.g. '> 2024 05 03 12 00 30.0000000', or\n")
+		fmt.Fprintf(fs.Output(), "                   RINEX 2 epoch, e.g. ' 24  5  3 12  0 30.0000000'\n")
+		fmt.Fprintf(fs.Output(), "  -                epochs read from stdin, one per line, e.g. with -format for batches,\n")
+		fmt.Fprintf(fs.Output(), "                   converted in parallel by -jobs goroutines in the order of the lines\n\n")
+		fmt.Fprintf(fs.Output(), "Dates (-dates):\n")
+		fmt.Fprintf(fs.Output(), "  calendar         2024-05-02 12:00:00 [default]\n")
+		fmt.Fprintf(fs.Output(), "  ordinal          ISO 8601 ordinal date, 2024-123 12:00:00\n")
+		fmt.Fprintf(fs.Output(), "  week             ISO 8601 week date, 2024-W18-4 12:00:00 (weekday 1 for Monday)\n\n")
+		fmt.Fprintf(fs.Output(), "Layouts:\n")
+		fmt.Fprintf(fs.Output(), "  %%Y 4-digit year     %%y 2-digit year    %%m month            %%d day\n")
+		fmt.Fprintf(fs.Output(), "  %%j doy              %%H hour            %%i minute           %%s second\n")
+		fmt.Fprintf(fs.Output(), "  %%W GNSS week        %%N day of week     %%S seconds of week  %%X seconds of day\n")
+		fmt.Fprintf(fs.Output(), "  %%M MJD              %%U Unix time       %%f nanoseconds      %%%% '%%'\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("epoch is not given")
+	}
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+
+	loc, err := dayBoundary(sys, *moscow)
+	if err != nil {
+		return err
+	}
+
+	ds, err := parseDateStyle(*dates)
+	if err != nil {
+		return err
+	}
+
+	var eop *iers.Finals
+	if *finals != "" {
+		if eop, err = loadFinals(ctx, *finals, *refresh); err != nil {
+			return err
+		}
+	}
+
+	var unit time.Duration
+	if *explain && (*format != "" || *jsonOut) {
+		return fmt.Errorf("-explain cannot be used with -format or -json")
+	}
+	if *tai && *bdt {
+		return fmt.Errorf("-tai and -bdt cannot be used together")
+	}
+	var scale string
+	if *tai {
+		scale = "tai"
+	} else if *bdt {
+		scale = "bdt"
+	}
+	if *unix != "" {
+		var ok bool
+		if unit, ok = gnss.UnixUnits[*unix]; !ok {
+			return fmt.Errorf("invalid unit of Unix time: '%s'", *unix)
+		}
+		if scale != "" {
+			return fmt.Errorf("-%s cannot be used with -unix", scale)
+		}
+	}
+
+	// parseArg returns the UTC time of an epoch
+	parseArg := func(arg string) (time.Time, error) {
+		return readEpoch(sys.Name, arg, *parse, unit, scale)
+	}
+
+	// newQuery returns the function printing the i-th epoch arg to out
+	newQuery := func(out io.Writer) func(i int, arg string) error {
+		enc := json.NewEncoder(out)
+		var buf []byte
+		return func(i int, arg string) error {
+			t, err := parseArg(arg)
+			if err != nil {
+				return err
+			}
+
+			if *jsonOut {
+				ti, err := newTimeInfo(sys, t)
+				if err != nil {
+					return err
+				}
+				if ds != dateCalendar {
+					ti.Date = ds.date(t)
+				}
+				return enc.Encode(ti)
+			}
+
+			if *format != "" {
+				if buf, err = gnss.AppendFormat(buf[:0], *format, sys.Name, t); err != nil {
+					return err
+				}
+				_, err = out.Write(append(buf, '\n'))
+				return err
+			}
+
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			if err := printTimeInfo(out, sys, t, loc, ds); err != nil {
+				return err
+			}
+			if eop != nil {
+				ut1, err := eop.UT1(t)
+				if err != nil {
+					return err
+				}
+				dut1, _ := eop.DUT1(t)
+				fmt.Fprintf(out, "%-14s%s (UT1-UTC %+.7f s)\n", "UT1", ds.dateTime(ut1.Round(time.Microsecond)), dut1.Seconds())
+			}
+			if *explain {
+				fmt.Fprintln(out)
+				return printExplanation(out, sys, t)
+			}
+			return nil
+		}
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	if fs.NArg() == 1 && fs.Arg(0) == "-" {
+		err = queryLines(ctx, os.Stdin, out, *jobs, newQuery)
+	} else {
+		query := newQuery(out)
+		for i, arg := range fs.Args() {
+			if err = query(i, arg); err != nil {
+				break
+			}
+		}
+	}
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// readEpoch returns the UTC time of the epoch arg of sys, parsed with
+// layout if given, or as Unix time in unit if not zero. If scale is "tai" or
+// "bdt", calendar epochs without a time zone (yyyy:ddd[:sod], yyyy-mm-dd[
+// hh:mm:ss], and layouts of calendar verbs) and epoch records are read in
+// TAI or BDT; the other forms are absolute, and are rejected.
+func readEpoch(sys gnss.SatSys, arg, layout string, unit time.Duration, scale string) (time.Time, error) {
+	var t time.Time
+	var err error
+	calendar := true
+	switch {
+	case unit != 0:
+		t, err = gnss.ParseUnix(arg, unit)
+		calendar = false
+	case layout != "":
+		t, err = gnss.Parse(layout, sys, arg)
+		calendar = calendarLayout(layout)
+	case scale != "" && gnss.IsEpochRecord(arg):
+		// the calendar of the record in GPS time, to be read in scale
+		// instead of the time scale of sys
+		if t, err = gnss.ParseTime(gnss.SYSGPS, arg); err == nil {
+			t = gnss.UTCToGPST(t)
+		}
+	default:
+		t, err = gnss.ParseTime(sys, arg)
+		calendar = calendarEpoch(arg)
+	}
+	if err != nil || scale == "" {
+		return t, err
+	}
+	if !calendar {
+		return time.Time{}, fmt.Errorf("-%s reads only calendar epochs without a time zone, not '%s'", scale, arg)
+	}
+	if scale == "tai" {
+		return gnss.TAIToUTC(t), nil
+	}
+	return gnss.BDTToUTC(t), nil
+}
+
+// calendarEpoch reports whether s is a calendar epoch without a time zone
+// in the forms of gnss.ParseTime, yyyy:ddd[:sod] or yyyy-mm-dd[ hh:mm:ss].
+func calendarEpoch(s string) bool {
+	s = strings.TrimSpace(s)
+	if len(s) >= 10 && s[4] == '-' && s[7] == '-' && (len(s) == 10 || s[10] == ' ') {
+		return true
+	}
+	f := strings.FieldsFunc(s, func(r rune) bool { return strings.ContainsRune(":-/_ \t", r) })
+	return len(f) >= 2 && len(f[0]) == 4 && len(f[1]) == 3
+}
+
+// calendarLayout reports whether layout has no verbs of absolute times,
+// i.e. GNSS week, day and seconds of week, MJD, or Unix time.
+func calendarLayout(layout string) bool {
+	for i := 0; i < len(layout)-1; i++ {
+		if layout[i] != '%' {
+			continue
+		}
+		i++
+		if strings.IndexByte("WNSMU", layout[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// queryBatch is the number of lines converted by a task of queryLines.
+const queryBatch = 1024
+
+// queryLines prints the epochs read from r, one per line, to w. Batches of
+// lines are converted by jobs goroutines, and printed in the order of the
+// lines. Blank lines are skipped, and errors tell the line number.
+func queryLines(ctx context.Context, r io.Reader, w io.Writer, jobs int, newQuery func(io.Writer) func(i int, arg string) error) error {
+	return writeOrdered(ctx, w, jobs, func(send func(task) bool) error {
+		sc := bufio.NewScanner(r)
+		var lines []string
+		var nums []int // line numbers
+		i := 0         // index of the first epoch of the batch
+		flush := func() bool {
+			lines, nums, first := lines, nums, i
+			i += len(lines)
+			return send(func() ([]byte, error) {
+				var b bytes.Buffer
+				query := newQuery(&b)
+				for k, line := range lines {
+					if err := query(first+k, line); err != nil {
+						return b.Bytes(), fmt.Errorf("line %d: %w", nums[k], err)
+					}
+				}
+				return b.Bytes(), nil
+			})
+		}
+		for n := 1; sc.Scan(); n++ {
+			line := sc.Text()
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			lines, nums = append(lines, line), append(nums, n)
+			if len(lines) == queryBatch {
+				if !flush() {
+					return nil
+				}
+				lines, nums = nil, nil
+			}
+		}
+		if len(lines) > 0 {
+			flush()
+		}
+		return sc.Err()
+	})
+}
+
+// printTimeInfo prints t (UTC) to w in the system time of sys.
+// The doy and the seconds of day are of the day in loc, and dates are
+// written in ds.
+func printTimeInfo(w io.Writer, sys gnss.SystemInfo, t time.Time, loc *time.Location, ds dateStyle) error {
+	g, err := gnss.TimeOf(sys.Name, t)
+	if err != nil {
+		return err
+	}
+
+	lt := t.In(loc)
+	day := time.Date(lt.Year(), lt.Month(), lt.Day(), 0, 0, 0, 0, loc)
+	sod := t.Sub(day).Seconds()
+	suffix := ""
+	if loc != time.UTC {
+		suffix = " (" + lt.Format("MST") + ")"
+	}
+
+	fmt.Fprintf(w, "%-14s%s\n", "UTC", ds.dateTime(t))
+	fmt.Fprintf(w, "%-14s%s\n", string(sys.Name)+" time", ds.dateTime(t.Add(sys.UTCOffset(t))))
+	fmt.Fprintf(w, "%-14s%s\n", "TAI", ds.dateTime(gnss.UTCToTAI(t)))
+	if sys.Name != gnss.SYSBDS {
+		fmt.Fprintf(w, "%-14s%s\n", "BDT", ds.dateTime(gnss.UTCToBDT(t)))
+	}
+	fmt.Fprintf(w, "%-14s%d\n", "Week", g.Week)
+	fmt.Fprintf(w, "%-14s%03d\n", "DOY"+suffix, lt.YearDay())
+	fmt.Fprintf(w, "%-14s%s\n", "SOD"+suffix, trimFloat(sod, 9))
+	fmt.Fprintf(w, "%-14s%s\n", "MJD", trimFloat(gnss.FractionalMJD(t), 10))
+	fmt.Fprintf(w, "%-14s%s (%s ms)\n", "Unix", gnss.FormatUnix(t, time.Second), gnss.FormatUnix(t, time.Millisecond))
+	fmt.Fprintf(w, "%-14s%s\n", "Progress", weekProgress(g))
+
+	return nil
+}
+
+// dayBoundary returns the location of day boundaries; UTC, or Moscow time
+// if moscow is true, which is allowed only for GLONASS.
+func dayBoundary(sys gnss.SystemInfo, moscow bool) (*time.Location, error) {
+	if !moscow {
+		return time.UTC, nil
+	}
+	if sys.Name != gnss.SYSGLO {
+		return nil, fmt.Errorf("Moscow day boundaries are applied only to GLO, not %s", sys.Name)
+	}
+	return gnss.Moscow, nil
+}
+
+// trimFloat formats f with prec digits of fraction, trimming trailing zeros.
+func trimFloat(f float64, prec int) string {
+	return strings.TrimSuffix(strings.TrimRight(strconv.FormatFloat(f, 'f', prec, 64), "0"), ".")
+}
+
+// formatSeconds formats t with the fraction of seconds if any.
+func formatSeconds(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05.999999999")
+}
+
+// loadFinals reads the finals2000A file of name. If name is an http or
+// https URL, the file is downloaded to the cache, and downloaded again when
+// older than a day or if refresh is true; the cached file is used with a
+// warning if the download fails.
+func loadFinals(ctx context.Context, name string, refresh bool) (*iers.Finals, error) {
+	if !strings.HasPrefix(name, "http://") && !strings.HasPrefix(name, "https://") {
+		return iers.LoadFile(name)
+	}
+	u, err := url.Parse(name)
+	if err != nil {
+		return nil, err
+	}
+	src := cache.Source{
+		Name:   path.Base(u.Path),
+		URL:    name,
+		MaxAge: oneDay,
+		Check: func(data []byte) (time.Time, error) {
+			_, err := iers.Load(bytes.NewReader(data))
+			return time.Time{}, err
+		},
+	}
+	data, _, err := cache.Get(ctx, src, refresh)
+	if data == nil {
+		return nil, err
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to refresh %s: %v; using the cache\n", name, err)
+	}
+	return iers.Load(bytes.NewReader(data))
+}
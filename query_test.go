@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+func TestReadEpochTAI(t *testing.T) {
+	noon := time.Date(2024, time.May, 2, 12, 0, 0, 0, time.UTC)
+	tai := noon.Add(-37 * time.Second) // TAI-UTC is 37 s in 2024
+	tests := []struct {
+		arg, layout string
+		want        time.Time
+	}{
+		{"2024:123:43200", "", tai},
+		{"2024:123:12:00:00", "", tai},
+		{"2024:123", "", tai.Add(-12 * time.Hour)},
+		{"2024-05-02", "", tai.Add(-12 * time.Hour)},
+		{"2024-05-02 12:00:00", "", tai},
+		{"2024123", "%Y%j", tai.Add(-12 * time.Hour)},
+		{"2024-05-02 43200", "%Y-%m-%d %X", tai},
+	}
+	for _, tt := range tests {
+		got, err := readEpoch(gnss.SYSGPS, tt.arg, tt.layout, 0, "tai")
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("readEpoch(%q, %q, tai) = %s, %v, want %s", tt.arg, tt.layout, got, err, tt.want)
+		}
+	}
+
+	// absolute epochs are not read in TAI
+	for _, tt := range []struct{ arg, layout string }{
+		{"2300:3:259200", ""},
+		{"2024-05-02T12:00:00Z", ""},
+		{"@1714651200", ""},
+		{"MJD60432.5", ""},
+		{"23004", "%W%N"},
+		{"1714651200", "%U"},
+		{"60432", "%M"},
+	} {
+		if got, err := readEpoch(gnss.SYSGPS, tt.arg, tt.layout, 0, "tai"); err == nil {
+			t.Errorf("readEpoch(%q, %q, tai) = %s, want error", tt.arg, tt.layout, got)
+		}
+		if _, err := readEpoch(gnss.SYSGPS, tt.arg, tt.layout, 0, ""); err != nil {
+			t.Errorf("readEpoch(%q, %q): %v", tt.arg, tt.layout, err)
+		}
+	}
+}
+
+func TestReadEpochBDT(t *testing.T) {
+	bdt := time.Date(2024, time.May, 3, 12, 0, 30, 0, time.UTC).Add(-4 * time.Second) // BDT-UTC is 4 s in 2024
+	tests := []struct {
+		sys         gnss.SatSys
+		arg, layout string
+	}{
+		{gnss.SYSGPS, "2024:124:43230", ""},
+		{gnss.SYSGPS, "2024:124:12:00:30", ""},
+		{gnss.SYSGPS, "2024-05-03 12:00:30", ""},
+		{gnss.SYSGPS, "2024124 43230", "%Y%j %X"},
+		{gnss.SYSGPS, "> 2024 05 03 12 00 30.0", ""},
+		{gnss.SYSGPS, " 24  5  3 12  0 30.0000000", ""},
+		{gnss.SYSBDS, "> 2024 05 03 12 00 30.0", ""},
+		{gnss.SYSGAL, "*  2024  5  3 12  0 30.00000000", ""},
+	}
+	for _, tt := range tests {
+		got, err := readEpoch(tt.sys, tt.arg, tt.layout, 0, "bdt")
+		if err != nil || !got.Equal(bdt) {
+			t.Errorf("readEpoch(%s, %q, %q, bdt) = %s, %v, want %s", tt.sys, tt.arg, tt.layout, got, err, bdt)
+		}
+	}
+
+	// the weeks of BDS are in BDT already
+	for _, arg := range []string{"956:5:43230", "2024-05-03T12:00:30Z", "@1714737626", "MJD60433.5"} {
+		if got, err := readEpoch(gnss.SYSBDS, arg, "", 0, "bdt"); err == nil {
+			t.Errorf("readEpoch(BDS, %q, bdt) = %s, want error", arg, got)
+		}
+	}
+}
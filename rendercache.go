@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// renderCache keeps the responses of the serve command rendered today, so
+// that dashboards refreshing every few seconds do not re-render the
+// calendars. The responses are keyed by the path and the query parameters,
+// i.e. the year, month, satsys and format, and are dropped when the day
+// changes, as the highlight of today moves.
+type renderCache struct {
+	mu      sync.Mutex
+	day     string // UTC date of the entries
+	entries map[string]*renderEntry
+	max     int // number of entries kept
+}
+
+// renderEntry is a cached response.
+type renderEntry struct {
+	body     []byte
+	header   http.Header // e.g. Content-Type
+	etag     string
+	modified time.Time
+}
+
+// newRenderCache returns a cache of up to max responses.
+func newRenderCache(max int) *renderCache {
+	return &renderCache{entries: map[string]*renderEntry{}, max: max}
+}
+
+// handler returns h serving from the cache with ETag and Last-Modified, and
+// answering conditional requests with 304 Not Modified. Only successful
+// responses to GET and HEAD are cached.
+func (c *renderCache) handler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			h(w, r)
+			return
+		}
+
+		key := r.URL.Path + "?" + r.URL.Query().Encode() // parameters sorted
+		now := time.Now().UTC()
+		e := c.get(key, now)
+		if e == nil {
+			rec := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+			h(rec, r)
+			if rec.status != http.StatusOK {
+				rec.copyTo(w)
+				return
+			}
+			e = c.put(key, now, rec)
+		}
+
+		for k, v := range e.header {
+			w.Header()[k] = v
+		}
+		w.Header().Set("ETag", e.etag)
+		w.Header().Set("Cache-Control", "no-cache") // revalidated by ETag
+		http.ServeContent(w, r, "", e.modified, bytes.NewReader(e.body))
+	}
+}
+
+// get returns the entry of key rendered on the day of now, or nil.
+func (c *renderCache) get(key string, now time.Time) *renderEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if day := now.Format("2006-01-02"); day != c.day {
+		c.day = day
+		c.entries = map[string]*renderEntry{}
+		return nil
+	}
+	return c.entries[key]
+}
+
+// put caches the response recorded in rec as the entry of key.
+func (c *renderCache) put(key string, now time.Time, rec *responseRecorder) *renderEntry {
+	h := fnv.New64a()
+	h.Write(rec.body.Bytes())
+	e := &renderEntry{
+		body:     rec.body.Bytes(),
+		header:   rec.header,
+		etag:     fmt.Sprintf(`"%016x"`, h.Sum64()),
+		modified: now.Truncate(time.Second),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.max {
+		c.entries = map[string]*renderEntry{} // start over rather than track usage
+	}
+	c.entries[key] = e
+	return e
+}
+
+// responseRecorder is an http.ResponseWriter keeping the response.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header         { return r.header }
+func (r *responseRecorder) Write(b []byte) (int, error) { return r.body.Write(b) }
+func (r *responseRecorder) WriteHeader(status int)      { r.status = status }
+
+// copyTo writes the recorded response to w.
+func (r *responseRecorder) copyTo(w http.ResponseWriter) {
+	for k, v := range r.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(r.status)
+	w.Write(r.body.Bytes())
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runRinex2 prints the RINEX v2 short filename of a station and date.
+func runRinex2(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("rinex2", flag.ExitOnError)
+	session := fs.String("session", "0", "session letter; 'a'-'x' for hourly, '0' for daily")
+	typ := fs.String("type", "o", "file type, e.g. 'o', 'n', 'g', 'm', or 'd'")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal rinex2 [-session s] [-type t] station [yyyy-mm-dd]\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("station is not given")
+	}
+	if len(*session) != 1 {
+		return fmt.Errorf("invalid session letter: '%s'", *session)
+	}
+	if len(*typ) != 1 {
+		return fmt.Errorf("invalid file type: '%s'", *typ)
+	}
+
+	date := time.Now().UTC().Truncate(oneDay)
+	if fs.NArg() > 1 {
+		var err error
+		if date, err = time.Parse("2006-01-02", fs.Arg(1)); err != nil {
+			return fmt.Errorf("invalid date: %s, error: %v", fs.Arg(1), err)
+		}
+	}
+
+	name, err := gnss.BuildRinex2Name(fs.Arg(0), date, (*session)[0], (*typ)[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println(name)
+
+	return nil
+}
+
+// runRinex3 prints the RINEX v3/v4 long filename of a station and date.
+func runRinex3(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("rinex3", flag.ExitOnError)
+	source := fs.String("source", "R", "data source; 'R' receiver, 'S' stream, or 'U' unknown")
+	start := fs.String("start", "0000", "start time of the file in hhmm")
+	period := fs.String("period", "01D", "file period, e.g. '01D', '01H', or '15M'")
+	frequency := fs.String("freq", "30S", "data frequency, e.g. '30S'; ignored for navigation files")
+	dataType := fs.String("type", "MO", "data type, e.g. 'MO', 'GN', or 'MM'")
+	format := fs.String("format", "crx", "'rnx' or 'crx'")
+	compression := fs.String("comp", "gz", "compression, e.g. 'gz'; '' if not compressed")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal rinex3 [flags] station [yyyy-mm-dd]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("station is not given")
+	}
+	if len(*source) != 1 {
+		return fmt.Errorf("invalid data source: '%s'", *source)
+	}
+
+	date := time.Now().UTC().Truncate(oneDay)
+	if fs.NArg() > 1 {
+		var err error
+		if date, err = time.Parse("2006-01-02", fs.Arg(1)); err != nil {
+			return fmt.Errorf("invalid date: %s, error: %v", fs.Arg(1), err)
+		}
+	}
+	hhmm, err := time.Parse("1504", *start)
+	if err != nil {
+		return fmt.Errorf("invalid start time: %s, error: %v", *start, err)
+	}
+	date = date.Add(time.Duration(hhmm.Hour())*time.Hour + time.Duration(hhmm.Minute())*time.Minute)
+
+	n := gnss.Rinex3Name{
+		Station:     fs.Arg(0),
+		Source:      (*source)[0],
+		Start:       date,
+		Period:      *period,
+		Frequency:   *frequency,
+		DataType:    *dataType,
+		Format:      *format,
+		Compression: *compression,
+	}
+	if len(n.DataType) == 2 && n.DataType[1] == 'N' {
+		n.Frequency = ""
+	}
+
+	name, err := gnss.BuildRinex3Name(n)
+	if err != nil {
+		return err
+	}
+	fmt.Println(name)
+
+	return nil
+}
+
+// runRinexInfo prints the dates and weeks parsed from RINEX filenames.
+func runRinexInfo(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("rinexinfo", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal rinexinfo filename...\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("filename is not given")
+	}
+
+	var failed int
+	for _, name := range fs.Args() {
+		info, err := gnss.ParseRinexName(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed++
+			continue
+		}
+		week, dow := "-", "-"
+		if !info.Date.Before(gnss.GPST0) {
+			week, dow = strconv.Itoa(info.Week), strconv.Itoa(info.Dow)
+		}
+		fmt.Printf("%s  station=%s date=%s doy=%03d week=%s dow=%s session=%c type=%s\n",
+			name, info.Station, info.Date.Format("2006-01-02 15:04"), info.Doy, week, dow, info.Session, info.Type)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d filenames are not parsed", failed, fs.NArg())
+	}
+	return nil
+}
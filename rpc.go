@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+)
+
+// rpcRequest is a JSON-RPC 2.0 request. A request without id is a
+// notification, which is not responded.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// error codes of JSON-RPC 2.0
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// rpcArgs are the arguments of the tools.
+type rpcArgs struct {
+	Epoch  string `json:"epoch"`
+	Parse  string `json:"parse"`
+	Date   string `json:"date"`
+	Week   *int   `json:"week"`
+	SatSys string `json:"satsys"`
+}
+
+// rpcTool is a conversion callable by tools/call, or by its name as the
+// method.
+type rpcTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+	call        func(args rpcArgs) (interface{}, error)
+}
+
+// schema returns the JSON schema of an object of properties, each of which
+// is a pair of a type and a description.
+func schema(required []string, props ...[3]string) map[string]interface{} {
+	p := map[string]interface{}{}
+	for _, v := range props {
+		p[v[0]] = map[string]string{"type": v[1], "description": v[2]}
+	}
+	return map[string]interface{}{"type": "object", "properties": p, "required": required}
+}
+
+var satsysProp = [3]string{"satsys", "string", "satellite system, e.g. GPS, GAL, BDS, GLO [default: GPS]"}
+
+var rpcTools = []rpcTool{
+	{
+		Name:        "convert",
+		Description: "Converts an epoch (yyyy:ddd[:sod], yyyy:ddd:hh:mm:ss, wwww:d[:sow], wwww:sow, yyyy-mm-dd[ hh:mm:ss], RFC 3339, @unix, MJDnnnnn.f, or a RINEX/SP3 epoch record) to UTC, GNSS week, dow, sow, doy, time of day, and MJD with the fraction of day.",
+		InputSchema: schema([]string{"epoch"},
+			[3]string{"epoch", "string", "epoch to be converted"},
+			[3]string{"parse", "string", "layout of epoch, e.g. '%Y%j' (%W week, %N dow, %S sow, %M MJD, ...)"},
+			satsysProp),
+		call: func(args rpcArgs) (interface{}, error) {
+			sys, err := lookupSatSysOrGPS(args.SatSys)
+			if err != nil {
+				return nil, err
+			}
+			t, err := parseEpoch(sys, args.Epoch, args.Parse)
+			if err != nil {
+				return nil, err
+			}
+			return newTimeInfo(sys, t)
+		},
+	},
+	{
+		Name:        "date",
+		Description: "Returns the GNSS week, dow, and doy of a date.",
+		InputSchema: schema([]string{"date"},
+			[3]string{"date", "string", "date, yyyy-mm-dd or yyyy:ddd"},
+			satsysProp),
+		call: func(args rpcArgs) (interface{}, error) {
+			sys, err := lookupSatSysOrGPS(args.SatSys)
+			if err != nil {
+				return nil, err
+			}
+			date, err := parseDate(args.Date)
+			if err != nil {
+				return nil, err
+			}
+			return newTimeInfo(sys, date)
+		},
+	},
+	{
+		Name:        "week",
+		Description: "Returns the start and end dates and the days (with doy) of a GNSS week.",
+		InputSchema: schema([]string{"week"},
+			[3]string{"week", "integer", "GNSS week"},
+			satsysProp),
+		call: func(args rpcArgs) (interface{}, error) {
+			sys, err := lookupSatSysOrGPS(args.SatSys)
+			if err != nil {
+				return nil, err
+			}
+			if args.Week == nil || *args.Week < 0 {
+				return nil, fmt.Errorf("invalid week")
+			}
+			return newWeekInfo(sys, *args.Week)
+		},
+	},
+}
+
+// runRPC serves the conversions by JSON-RPC 2.0 on stdin and stdout, one
+// message per line, as a Model Context Protocol (MCP) server.
+func runRPC(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal mcp\n\n")
+		fmt.Fprintf(fs.Output(), "Methods (JSON-RPC 2.0 on stdin/stdout, one message per line):\n")
+		fmt.Fprintf(fs.Output(), "  initialize, ping, tools/list, tools/call   Model Context Protocol\n")
+		fmt.Fprintf(fs.Output(), "  convert {epoch, parse, satsys}            GNSS time of an epoch\n")
+		fmt.Fprintf(fs.Output(), "  date {date, satsys}                       GNSS week and doy of a date\n")
+		fmt.Fprintf(fs.Output(), "  week {week, satsys}                       days of a GNSS week\n")
+	}
+	fs.Parse(args)
+
+	return serveRPC(ctx, os.Stdin, os.Stdout)
+}
+
+// serveRPC serves JSON-RPC requests read from r until EOF, or until ctx is
+// done.
+func serveRPC(ctx context.Context, r io.Reader, w io.Writer) error {
+	lines := make(chan []byte)
+	errc := make(chan error, 1)
+	go func() {
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		for sc.Scan() {
+			select {
+			case lines <- append([]byte(nil), sc.Bytes()...):
+			case <-ctx.Done():
+				return
+			}
+		}
+		errc <- sc.Err()
+	}()
+
+	enc := json.NewEncoder(w)
+	for {
+		var line []byte
+		select {
+		case line = <-lines:
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+		if len(line) == 0 {
+			continue
+		}
+		var req rpcRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			if err := enc.Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+				Error: &rpcError{rpcParseError, err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, rerr := handleRPC(req)
+		if req.ID == nil {
+			continue
+		}
+		if err := enc.Encode(rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rerr}); err != nil {
+			return err
+		}
+	}
+}
+
+// handleRPC returns the result of req.
+func handleRPC(req rpcRequest) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": "2024-11-05",
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "gnsscal", "version": buildVersion()},
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": rpcTools}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &rpcError{rpcInvalidParams, err.Error()}
+		}
+		tool, ok := findTool(p.Name)
+		if !ok {
+			return nil, &rpcError{rpcInvalidParams, "unknown tool: " + p.Name}
+		}
+		// errors of tools are results of MCP to be seen by the caller
+		result, err := callTool(tool, p.Arguments)
+		if err != nil {
+			return toolResult(err.Error(), true), nil
+		}
+		b, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return toolResult(err.Error(), true), nil
+		}
+		return toolResult(string(b), false), nil
+	}
+
+	if tool, ok := findTool(req.Method); ok {
+		result, err := callTool(tool, req.Params)
+		if err != nil {
+			return nil, &rpcError{rpcInvalidParams, err.Error()}
+		}
+		return result, nil
+	}
+	if req.ID == nil {
+		// notifications such as notifications/initialized
+		return nil, nil
+	}
+	return nil, &rpcError{rpcMethodNotFound, "method not found: " + req.Method}
+}
+
+func findTool(name string) (rpcTool, bool) {
+	for _, t := range rpcTools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return rpcTool{}, false
+}
+
+func callTool(tool rpcTool, params json.RawMessage) (interface{}, error) {
+	var args rpcArgs
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, err
+		}
+	}
+	return tool.call(args)
+}
+
+// toolResult is the result of tools/call with a text content.
+func toolResult(text string, isError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// buildVersion returns the module version of the binary.
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// rpcReply is a response of serveRPC, with the result left undecoded.
+type rpcReply struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+// callRPC serves the lines of in and returns the responses.
+func callRPC(t *testing.T, in ...string) []rpcReply {
+	t.Helper()
+	var out bytes.Buffer
+	if err := serveRPC(context.Background(), strings.NewReader(strings.Join(in, "\n")+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	var replies []rpcReply
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var r rpcReply
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		replies = append(replies, r)
+	}
+	return replies
+}
+
+func TestServeRPC(t *testing.T) {
+	replies := callRPC(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		``,
+		`{"jsonrpc":"2.0","id":"a","method":"ping"}`,
+		`{"jsonrpc":"2.0","method":"convert","params":{"epoch":"2024:123"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	)
+	if len(replies) != 3 {
+		t.Fatalf("%d responses, want 3 (none to notifications)", len(replies))
+	}
+	for i, id := range []string{`1`, `"a"`, `2`} {
+		r := replies[i]
+		if r.JSONRPC != "2.0" || string(r.ID) != id || r.Error != nil || len(r.Result) == 0 {
+			t.Errorf("response %d = %s %s %v %s, want 2.0 %s with a result", i, r.JSONRPC, r.ID, r.Error, r.Result, id)
+		}
+	}
+
+	var info struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+	}
+	if err := json.Unmarshal(replies[0].Result, &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.ProtocolVersion == "" || info.ServerInfo.Name != "gnsscal" {
+		t.Errorf("initialize = %s, want the protocol version and the server name", replies[0].Result)
+	}
+
+	var list struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(replies[2].Result, &list); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+	}
+	if got, want := strings.Join(names, ","), "convert,date,week"; got != want {
+		t.Errorf("tools = %s, want %s", got, want)
+	}
+}
+
+func TestServeRPCTools(t *testing.T) {
+	replies := callRPC(t,
+		`{"jsonrpc":"2.0","id":1,"method":"convert","params":{"epoch":"2024:123:43200"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"week","params":{"week":2312,"satsys":"GAL"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"date","arguments":{"date":"2024-05-02"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"convert","arguments":{"epoch":"x"}}}`,
+	)
+	if len(replies) != 4 {
+		t.Fatalf("%d responses, want 4", len(replies))
+	}
+
+	var ti timeInfo
+	if err := json.Unmarshal(replies[0].Result, &ti); err != nil {
+		t.Fatal(err)
+	}
+	if ti.Week != 2312 || ti.Dow != 4 || ti.UTC != "2024-05-02T12:00:00Z" {
+		t.Errorf("convert = %s, want GPS week 2312 dow 4 at 2024-05-02T12:00:00Z", replies[0].Result)
+	}
+
+	var wi weekInfo
+	if err := json.Unmarshal(replies[1].Result, &wi); err != nil {
+		t.Fatal(err)
+	}
+	if wi.SatSys != "GAL" || wi.Week != 2312 || len(wi.Days) != 7 {
+		t.Errorf("week = %s %d with %d days, want GAL 2312 with 7 days", wi.SatSys, wi.Week, len(wi.Days))
+	}
+
+	// results of tools/call are text contents, and errors are results too
+	for i, wantError := range []bool{false, true} {
+		r := replies[2+i]
+		var res struct {
+			Content []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+			IsError bool `json:"isError"`
+		}
+		if r.Error != nil {
+			t.Errorf("tools/call %s: error %v, want a result", r.ID, r.Error)
+			continue
+		}
+		if err := json.Unmarshal(r.Result, &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Content) != 1 || res.Content[0].Type != "text" || res.Content[0].Text == "" || res.IsError != wantError {
+			t.Errorf("tools/call %s = %s, want a text content with isError %v", r.ID, r.Result, wantError)
+		}
+	}
+	if !strings.Contains(string(replies[2].Result), `\"doy\": 123`) {
+		t.Errorf("tools/call date = %s, want doy 123", replies[2].Result)
+	}
+}
+
+func TestServeRPCError(t *testing.T) {
+	replies := callRPC(t,
+		`{"jsonrpc":"2.0","id":1,`,
+		`{"jsonrpc":"2.0","id":2,"method":"unknown"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"convert","params":{"epoch":"x"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"unknown"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":[]}`,
+		`{"jsonrpc":"2.0","id":6,"method":"week","params":{"week":"x"}}`,
+		`{"jsonrpc":"2.0","method":"unknown"}`,
+	)
+	want := []struct {
+		id   string
+		code int
+	}{
+		{`null`, rpcParseError},
+		{`2`, rpcMethodNotFound},
+		{`3`, rpcInvalidParams},
+		{`4`, rpcInvalidParams},
+		{`5`, rpcInvalidParams},
+		{`6`, rpcInvalidParams},
+	}
+	if len(replies) != len(want) {
+		t.Fatalf("%d responses, want %d (none to notifications)", len(replies), len(want))
+	}
+	for i, w := range want {
+		r := replies[i]
+		if string(r.ID) != w.id || r.Error == nil || r.Error.Code != w.code || r.Error.Message == "" || r.Result != nil {
+			t.Errorf("response %d = %s %v %s, want %s with error %d", i, r.ID, r.Error, r.Result, w.id, w.code)
+		}
+	}
+}
+
+func TestServeRPCDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	if err := serveRPC(ctx, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`+"\n"), &out); err != nil {
+		t.Errorf("serveRPC = %v, want nil when ctx is done", err)
+	}
+}
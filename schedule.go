@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runSchedule prints the dates of a date range matching a rule, annotated
+// with doy and GNSS week/dow, e.g. to schedule periodic reprocessing jobs.
+func runSchedule(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("schedule", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	format := fs.String("format", "text", "output format; 'text' or 'csv'")
+	dates := fs.String("dates", "calendar", "notation of dates; 'calendar' (2024-05-02), 'ordinal' (2024-123), or 'week' (2024-W18-4)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal schedule [-satsys sys] [-format text|csv] [-dates style] rule from to\n\n")
+		fmt.Fprintf(fs.Output(), "The dates from and to (inclusive) are yyyy-mm-dd or yyyy:ddd. The rule is one of\n")
+		fmt.Fprintf(fs.Output(), "  every:n     every n-th day from the date from\n")
+		fmt.Fprintf(fs.Output(), "  dow:d       day of GNSS week d (0-6), e.g. dow:0 for the days of weekly products\n")
+		fmt.Fprintf(fs.Output(), "  monthday:d  day of month d, e.g. monthday:1 for the first day of each month,\n")
+		fmt.Fprintf(fs.Output(), "              or monthday:-1 for the last\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 3 {
+		fs.Usage()
+		return fmt.Errorf("rule and date range are not given")
+	}
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+	ds, err := parseDateStyle(*dates)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "text", "csv":
+	default:
+		return fmt.Errorf("invalid format: '%s'. valid formats: text, csv", *format)
+	}
+
+	from, err := parseDate(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	to, err := parseDate(fs.Arg(2))
+	if err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return fmt.Errorf("invalid date range: %s is before %s", fs.Arg(2), fs.Arg(1))
+	}
+	r := gnss.DateRange{Start: from, End: to}
+
+	rule, err := parseRule(fs.Arg(0), r)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{{"Date", "DOY", "Week", "Dow"}}
+	for d := range gnss.Schedule(sys.Name, r, rule) {
+		week, dow := "", ""
+		if d.Week >= 0 {
+			week, dow = strconv.Itoa(d.Week), strconv.Itoa(d.Dow)
+		}
+		rows = append(rows, []string{ds.date(d.Date), fmt.Sprintf("%03d", d.Doy), week, dow})
+	}
+
+	if *format == "csv" {
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+	printColumns(rows)
+	return nil
+}
+
+// parseRule parses a rule of the schedule command over r; see its usage.
+func parseRule(s string, r gnss.DateRange) (gnss.Rule, error) {
+	name, arg, ok := strings.Cut(s, ":")
+	n, err := strconv.Atoi(arg)
+	if !ok || err != nil {
+		return nil, fmt.Errorf("invalid rule: '%s'. valid rules: every:n, dow:d, monthday:d", s)
+	}
+
+	switch name {
+	case "every":
+		if n < 1 {
+			return nil, fmt.Errorf("invalid rule: '%s'. n of every:n must be positive", s)
+		}
+		return gnss.EveryNthDay(n, r.Start), nil
+	case "dow":
+		if n < 0 || n > 6 {
+			return nil, fmt.Errorf("invalid rule: '%s'. d of dow:d must be 0-6", s)
+		}
+		return gnss.WeekDay(n), nil
+	case "monthday":
+		if n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("invalid rule: '%s'. d of monthday:d must be 1-31, or -1 to -31 from the end", s)
+		}
+		return gnss.MonthDay(n), nil
+	}
+	return nil, fmt.Errorf("invalid rule: '%s'. valid rules: every:n, dow:d, monthday:d", s)
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/calendar"
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// layoutNames maps the values of the layout query parameter to layouts.
+var layoutNames = map[string]calendar.Layout{
+	"month":   calendar.Layout1Month,
+	"3month":  calendar.Layout3Month,
+	"quarter": calendar.LayoutQuarter,
+	"weeks":   calendar.LayoutWeekRows,
+	"year":    calendar.Layout1Year,
+	"chart":   calendar.LayoutYearChart,
+	"plain":   calendar.LayoutPlain,
+}
+
+// cellNames maps the values of the cell query parameter to cell formats.
+var cellNames = map[string]calendar.CellFormat{
+	"day":  calendar.CellDay,
+	"wd":   calendar.CellWeekDow,
+	"both": calendar.CellBoth,
+}
+
+// calendarPage is the HTML page of the serve command.
+var calendarPage = template.Must(template.New("calendar").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gnsscal {{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+pre { font-family: monospace; font-size: 14px; line-height: 1.3; }
+.today { background: #333; color: #fff; }
+.leap { text-decoration: underline; color: #c00; }
+.event { font-weight: bold; color: #06c; }
+.eclipse { background: #ddd; }
+.week { background: #cde; }
+nav a { margin-right: 1em; }
+</style>
+</head>
+<body>
+<nav>{{if .Prev}}<a href="{{.Prev}}">&laquo; prev</a>{{end}}{{if .Up}}<a href="{{.Up}}">up</a>{{end}}{{if .Today}}<a href="{{.Today}}">today</a>{{end}}{{if .Next}}<a href="{{.Next}}">next &raquo;</a>{{end}}</nav>
+<pre>{{.Calendar}}</pre>
+</body>
+</html>
+`))
+
+// runServe runs an HTTP server rendering GNSS calendars.
+func runServe(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	events := fs.String("events", "", "file of campaign events, one 'yyyy-mm-dd name' per line, for the feeds")
+	cacheSize := fs.Int("cache", 1024, "number of rendered pages and API responses kept in memory")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal serve [-addr host:port] [-events file] [-cache n]\n\n")
+		fmt.Fprintf(fs.Output(), "Pages:\n")
+		fmt.Fprintf(fs.Output(), "  /          calendar of the current month\n")
+		fmt.Fprintf(fs.Output(), "  /YYYY      calendar of a year\n")
+		fmt.Fprintf(fs.Output(), "  /YYYY/MM   calendar of a month\n\n")
+		fmt.Fprintf(fs.Output(), "Query parameters:\n")
+		fmt.Fprintf(fs.Output(), "  satsys     satellite system, e.g. GPS or GAL\n")
+		fmt.Fprintf(fs.Output(), "  layout     month, 3month, quarter, weeks, year, chart, or plain\n")
+		fmt.Fprintf(fs.Output(), "  cell       day, wd, or both\n")
+		fmt.Fprintf(fs.Output(), "  locale     language of month and weekday names, e.g. ja or de\n")
+		fmt.Fprintf(fs.Output(), "  first      weekday of the first column, e.g. mon or sat\n")
+		fmt.Fprintf(fs.Output(), "  j, d, W, offset, leap, era, events, eclipse, footer, border\n")
+		fmt.Fprintf(fs.Output(), "             1 to enable -j, -d, -W, -offset, -leap, -era, -events, -eclipse, -footer, and -border\n")
+		fmt.Fprintf(fs.Output(), "             of the calendar\n\n")
+		fmt.Fprintf(fs.Output(), "Subscriptions:\n")
+		fmt.Fprintf(fs.Output(), "  /ics/YYYY.ics   iCalendar of GNSS weeks of a year (satsys=... selects the system),\n")
+		fmt.Fprintf(fs.Output(), "                  e.g. webcal://host:8080/ics/2024.ics?satsys=GAL\n")
+		fmt.Fprintf(fs.Output(), "  /feed.atom      Atom feed of upcoming week rollovers, leap seconds, and campaign\n")
+		fmt.Fprintf(fs.Output(), "                  events given by -events\n")
+		fmt.Fprintf(fs.Output(), "  /feed.rss       RSS feed of the same\n\n")
+		fmt.Fprintf(fs.Output(), "API (JSON):\n")
+		fmt.Fprintf(fs.Output(), "  /api/v1/convert?epoch=...   epoch in the forms of query, or the layout of parse=...\n")
+		fmt.Fprintf(fs.Output(), "  /api/v1/date/{date}         date, yyyy-mm-dd or yyyy:ddd\n")
+		fmt.Fprintf(fs.Output(), "  /api/v1/week/{week}         days of a GNSS week\n")
+		fmt.Fprintf(fs.Output(), "  /api/v1/month/{yyyy}/{mm}   grid of a month, rows of weeks from first=... (e.g. mon)\n")
+		fmt.Fprintf(fs.Output(), "                              with the doy, GNSS week/dow and flags of each day\n")
+		fmt.Fprintf(fs.Output(), "  satsys=... selects the satellite system\n\n")
+		fmt.Fprintf(fs.Output(), "Monitoring:\n")
+		fmt.Fprintf(fs.Output(), "  /metrics                    Prometheus gauges of the current GNSS week, dow, sow,\n")
+		fmt.Fprintf(fs.Output(), "                              doy, and offsets from UTC\n")
+		fmt.Fprintf(fs.Output(), "  /badge                      SVG badge of the current GNSS week and doy, e.g.\n")
+		fmt.Fprintf(fs.Output(), "                              'GPS week 2313 / DOY 123' (satsys=... selects the system)\n")
+		fmt.Fprintf(fs.Output(), "\nCaching:\n")
+		fmt.Fprintf(fs.Output(), "  Pages, iCalendars and API responses are kept in memory (-cache entries) until\n")
+		fmt.Fprintf(fs.Output(), "  the day changes, and served with ETag and Last-Modified for conditional requests\n")
+	}
+	fs.Parse(args)
+
+	var campaigns []upcomingEvent
+	if *events != "" {
+		var err error
+		if campaigns, err = loadEvents(*events); err != nil {
+			return err
+		}
+	}
+
+	// calendars and conversions are cached until the day changes
+	rc := newRenderCache(*cacheSize)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", rc.handler(handleCalendar))
+	registerAPI(mux, rc.handler)
+	mux.HandleFunc("/ics/", rc.handler(handleICS))
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/badge", handleBadge)
+	mux.HandleFunc("/feed.atom", feedHandler(campaigns, true))
+	mux.HandleFunc("/feed.rss", feedHandler(campaigns, false))
+
+	srv := &http.Server{
+		Addr:        *addr,
+		Handler:     mux,
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	// an interrupt waits for the requests in progress for up to 5 seconds
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	log.Printf("gnsscal serving on %s", *addr)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	<-done
+	log.Printf("gnsscal shut down")
+	return nil
+}
+
+// handleCalendar renders the calendar of the path /YYYY or /YYYY/MM.
+func handleCalendar(w http.ResponseWriter, r *http.Request) {
+	cal, err := calFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	text := cal.String()
+	if cal.Leap {
+		text += "\n\n" + strings.Join(cal.LeapFootnote(), "\n")
+	}
+	if cal.Events != nil {
+		text += "\n\n" + strings.Join(cal.EventFootnote(), "\n")
+	}
+	if cal.Eclipse {
+		text += "\n\n" + strings.Join(cal.EclipseFootnote(), "\n")
+	}
+
+	path := func(t time.Time) string { return fmt.Sprintf("/%04d/%02d", t.Year(), int(t.Month())) }
+	prev, next, yearly := navDates(cal)
+	if yearly {
+		path = func(t time.Time) string { return fmt.Sprintf("/%04d", t.Year()) }
+	}
+	query := ""
+	if r.URL.RawQuery != "" {
+		query = "?" + r.URL.RawQuery
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = renderPage(w, page{
+		Title:    strings.TrimPrefix(r.URL.Path, "/"),
+		Calendar: text,
+		Prev:     path(prev) + query,
+		Next:     path(next) + query,
+		Today:    "/" + query,
+	})
+	if err != nil {
+		log.Printf("failed to render %s: %v", r.URL, err)
+	}
+}
+
+// page is a calendar page with the links of the navigation.
+type page struct {
+	Title, Calendar       string
+	Prev, Next, Today, Up string
+}
+
+// renderPage writes the HTML page of p. Links left empty are omitted.
+func renderPage(w io.Writer, p page) error {
+	return calendarPage.Execute(w, map[string]interface{}{
+		"Title":    p.Title,
+		"Calendar": template.HTML(ansiToHTML(p.Calendar)),
+		"Prev":     p.Prev,
+		"Next":     p.Next,
+		"Today":    p.Today,
+		"Up":       p.Up,
+	})
+}
+
+// navDates returns the reference dates of the previous and next pages of
+// cal, and whether the pages are of years rather than of months.
+func navDates(cal *calendar.Calendar) (prev, next time.Time, yearly bool) {
+	switch cal.Layout {
+	case calendar.Layout1Year, calendar.LayoutYearChart:
+		return cal.RefDate.AddDate(-1, 0, 0), cal.RefDate.AddDate(1, 0, 0), true
+	case calendar.LayoutWeekRows, calendar.LayoutPlain:
+		if cal.WholeYear {
+			return cal.RefDate.AddDate(-1, 0, 0), cal.RefDate.AddDate(1, 0, 0), true
+		}
+		return monthStart(cal.RefDate, -1), monthStart(cal.RefDate, 1), false
+	case calendar.LayoutQuarter:
+		first := time.Date(cal.RefDate.Year(), (cal.RefDate.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+		return first.AddDate(0, -3, 0), first.AddDate(0, 3, 0), false
+	default:
+		return monthStart(cal.RefDate, -1), monthStart(cal.RefDate, 1), false
+	}
+}
+
+// monthStart returns the first day of the month n months after the month
+// of date.
+func monthStart(date time.Time, n int) time.Time {
+	return time.Date(date.Year(), date.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// calFromRequest returns the calendar specified by the path and the query
+// parameters of r.
+func calFromRequest(r *http.Request) (cal *calendar.Calendar, err error) {
+	return calFromQuery(r.URL.Path, r.URL.Query())
+}
+
+// calFromQuery returns the calendar specified by the path, /YYYY or
+// /YYYY/MM, and the query parameters q.
+func calFromQuery(path string, q url.Values) (cal *calendar.Calendar, err error) {
+	var opts []calendar.Option
+
+	// path
+	var parts []string
+	if p := strings.Trim(path, "/"); p != "" {
+		parts = strings.Split(p, "/")
+	}
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("invalid path: %s", path)
+	}
+	var year int
+	if len(parts) > 0 {
+		if year, err = strconv.Atoi(parts[0]); err != nil || year < 1980 || year > 9999 {
+			return nil, fmt.Errorf("%w: %s", calendar.ErrInvalidYear, parts[0])
+		}
+		opts = append(opts, calendar.WithYear(year))
+	}
+	if len(parts) > 1 {
+		month, err := strconv.Atoi(parts[1])
+		if err != nil || month < 1 || month > 12 {
+			return nil, fmt.Errorf("%w: %s", calendar.ErrInvalidMonth, parts[1])
+		}
+		opts = append(opts,
+			calendar.WithReference(time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)),
+			calendar.WithLayout(calendar.Layout1Month))
+	}
+
+	// query parameters
+	if v := q.Get("satsys"); v != "" {
+		sys, err := gnss.ParseSatSys(v)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, calendar.WithSatSys(sys))
+	}
+	if v := q.Get("layout"); v != "" {
+		layout, ok := layoutNames[v]
+		if !ok {
+			return nil, fmt.Errorf("invalid layout: '%s'. valid layouts: month, 3month, quarter, weeks, year, chart, plain", v)
+		}
+		opts = append(opts, calendar.WithLayout(layout))
+	}
+	if v := q.Get("cell"); v != "" {
+		cell, ok := cellNames[v]
+		if !ok {
+			return nil, fmt.Errorf("invalid cell format: '%s'. valid formats: day, wd, both", v)
+		}
+		opts = append(opts, calendar.WithCell(cell))
+	}
+	if v := q.Get("locale"); v != "" {
+		locale, ok := calendar.LookupLocale(v)
+		if !ok {
+			return nil, fmt.Errorf("invalid locale: '%s'. valid locales: %s", v, strings.Join(calendar.LocaleNames(), ", "))
+		}
+		opts = append(opts, calendar.WithLocale(locale), calendar.WithFirstWeekday(locale.FirstWeekday))
+	}
+	if v := q.Get("first"); v != "" {
+		wd, err := parseWeekday(v)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, calendar.WithFirstWeekday(wd))
+	}
+	opts = append(opts,
+		calendar.WithJulian(q.Get("j") == "1"),
+		calendar.WithDow(q.Get("d") == "1"),
+		calendar.WithWholeWeek(q.Get("W") == "1"),
+		calendar.WithOffset(q.Get("offset") == "1"),
+		calendar.WithLeap(q.Get("leap") == "1"),
+		calendar.WithEra(q.Get("era") == "1"),
+		calendar.WithEclipse(q.Get("eclipse") == "1"),
+		calendar.WithFooter(q.Get("footer") == "1"),
+		calendar.WithBorder(q.Get("border") == "1"),
+	)
+	if q.Get("events") == "1" {
+		opts = append(opts, calendar.WithEvents(calendar.GNSSEvents()))
+	}
+
+	return calendar.NewCalendar(opts...)
+}
+
+// ansiToHTML escapes s and replaces the highlight escape sequences with spans.
+func ansiToHTML(s string) string {
+	s = html.EscapeString(s)
+	return strings.NewReplacer(
+		"\033[7m", `<span class="today">`,
+		"\033[4m", `<span class="leap">`,
+		"\033[1m", `<span class="event">`,
+		"\033[100m", `<span class="eclipse">`,
+		"\033[44m", `<span class="week">`,
+		"\033[0m", `</span>`,
+	).Replace(s)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/satoshi-pes/gnsscal/calendar"
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+func TestCalFromQueryError(t *testing.T) {
+	tests := []struct {
+		path  string
+		query string
+		want  error  // matched by errors.Is, if not nil
+		msg   string // prefix of the message
+	}{
+		{"/2024/5/1", "", nil, "invalid path"},
+		{"/1979", "", calendar.ErrInvalidYear, ""},
+		{"/10000", "", calendar.ErrInvalidYear, ""},
+		{"/x", "", calendar.ErrInvalidYear, ""},
+		{"/2024/0", "", calendar.ErrInvalidMonth, ""},
+		{"/2024/13", "", calendar.ErrInvalidMonth, ""},
+		{"/2024", "satsys=XYZ", gnss.ErrUnknownSatSys, ""},
+		{"/2024", "layout=x", nil, "invalid layout: 'x'"},
+		{"/2024", "cell=x", nil, "invalid cell format: 'x'"},
+		{"/2024", "locale=xx", nil, "invalid locale: 'xx'"},
+		{"/2024", "first=x", nil, ""},
+	}
+	for _, tt := range tests {
+		q, _ := url.ParseQuery(tt.query)
+		_, err := calFromQuery(tt.path, q)
+		if err == nil {
+			t.Errorf("calFromQuery(%q, %q) succeeded, want error", tt.path, tt.query)
+			continue
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("calFromQuery(%q, %q) = %v, want %v", tt.path, tt.query, err, tt.want)
+		}
+		if !strings.HasPrefix(err.Error(), tt.msg) {
+			t.Errorf("calFromQuery(%q, %q) = %v, want %q...", tt.path, tt.query, err, tt.msg)
+		}
+	}
+}
+
+func TestCalFromQuery(t *testing.T) {
+	q, _ := url.ParseQuery("satsys=GAL&locale=de")
+	cal, err := calFromQuery("/2024/5", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y, m := cal.RefDate.Year(), cal.RefDate.Month(); y != 2024 || m != 5 {
+		t.Errorf("reference = %v, want 2024-05", cal.RefDate)
+	}
+	if cal.SatSys != gnss.SYSGAL {
+		t.Errorf("satsys = %s, want %s", cal.SatSys, gnss.SYSGAL)
+	}
+}
+
+// serveAPI returns the response of the REST API to a GET of target.
+func serveAPI(t *testing.T, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	registerAPI(mux, func(h http.HandlerFunc) http.HandlerFunc { return h })
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("GET %s: Content-Type = %q, want application/json", target, ct)
+	}
+	return w
+}
+
+func TestHandleConvert(t *testing.T) {
+	w := serveAPI(t, "/api/v1/convert?epoch=2024:123:43200")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var ti timeInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &ti); err != nil {
+		t.Fatal(err)
+	}
+	want := timeInfo{SatSys: gnss.SYSGPS, UTC: "2024-05-02T12:00:00Z", Week: 2312, Dow: 4, Year: 2024, Doy: 123, Hour: 12, MJD: 60432}
+	if ti.SatSys != want.SatSys || ti.UTC != want.UTC || ti.Week != want.Week || ti.Dow != want.Dow ||
+		ti.Year != want.Year || ti.Doy != want.Doy || ti.Hour != want.Hour || ti.MJD != want.MJD {
+		t.Errorf("convert = %+v, want %+v", ti, want)
+	}
+
+	for _, target := range []string{
+		"/api/v1/convert",
+		"/api/v1/convert?epoch=x",
+		"/api/v1/convert?epoch=2024:123&satsys=XYZ",
+		"/api/v1/convert?epoch=2024&parse=%25Y-%25m",
+	} {
+		w := serveAPI(t, target)
+		var e apiError
+		if err := json.Unmarshal(w.Body.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != http.StatusBadRequest || e.Error == "" {
+			t.Errorf("GET %s = %d %s, want %d with an error", target, w.Code, w.Body, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestHandleWeek(t *testing.T) {
+	w := serveAPI(t, "/api/v1/week/2312?satsys=GPS")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var info weekInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.SatSys != gnss.SYSGPS || info.Week != 2312 || info.Start != "2024-04-28" || info.End != "2024-05-04" {
+		t.Errorf("week = %s %d %s..%s, want GPS 2312 2024-04-28..2024-05-04", info.SatSys, info.Week, info.Start, info.End)
+	}
+	if len(info.Days) != 7 {
+		t.Fatalf("%d days, want 7", len(info.Days))
+	}
+	for i, d := range info.Days {
+		if d.Week != 2312 || d.Dow != i {
+			t.Errorf("day %d: week %d dow %d, want 2312 %d", i, d.Week, d.Dow, i)
+		}
+	}
+
+	for _, target := range []string{
+		"/api/v1/week/x",
+		"/api/v1/week/-1",
+		"/api/v1/week/500000",
+		"/api/v1/week/10?satsys=GLO",
+	} {
+		if w := serveAPI(t, target); w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want %d", target, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestRenderCache(t *testing.T) {
+	calls := 0
+	h := newRenderCache(10).handler(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("bad") != "" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("calendar"))
+	})
+	get := func(target, etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		h(w, r)
+		return w
+	}
+
+	w := get("/2024?a=1&b=2", "")
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || w.Body.String() != "calendar" || etag == "" {
+		t.Fatalf("GET = %d %q ETag %q, want 200 calendar with an ETag", w.Code, w.Body, etag)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "no-cache" {
+		t.Errorf("Cache-Control = %q, want no-cache", cc)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/plain" {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+
+	// the same parameters in another order are served from the cache
+	if w := get("/2024?b=2&a=1", etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("GET with If-None-Match = %d %q, want 304 without a body", w.Code, w.Body)
+	}
+	if w := get("/2024?a=1&b=2", `"other"`); w.Code != http.StatusOK || w.Body.String() != "calendar" {
+		t.Errorf("GET with another ETag = %d %q, want 200 calendar", w.Code, w.Body)
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+
+	// errors are not cached
+	for i := 0; i < 2; i++ {
+		if w := get("/2024?bad=1", ""); w.Code != http.StatusBadRequest || w.Header().Get("ETag") != "" {
+			t.Errorf("GET of an error = %d ETag %q, want 400 without an ETag", w.Code, w.Header().Get("ETag"))
+		}
+	}
+	if calls != 3 {
+		t.Errorf("handler called %d times, want 3", calls)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runSessions prints the hourly RINEX session letters of a day.
+func runSessions(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("sessions", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal sessions [yyyy-mm-dd]\n")
+	}
+	fs.Parse(args)
+
+	date := time.Now().UTC().Truncate(oneDay)
+	if fs.NArg() > 0 {
+		var err error
+		if date, err = time.Parse("2006-01-02", fs.Arg(0)); err != nil {
+			return fmt.Errorf("invalid date: %s, error: %v", fs.Arg(0), err)
+		}
+	}
+
+	fmt.Printf("Sessions of %s (DOY %03d)\n", date.Format("2006-01-02"), doy(date))
+	fmt.Printf("%-9s%s\n", "Session", "UTC hours")
+	for hour := 0; hour < 24; hour++ {
+		letter, _ := gnss.SessionLetter(hour)
+		fmt.Printf("%-9c%02d:00-%02d:00\n", letter, hour, hour+1)
+	}
+	fmt.Printf("%-9c%s\n", gnss.DailySession, "00:00-24:00 (daily)")
+
+	return nil
+}
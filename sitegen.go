@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// indexPage is the top page of the site generated by gen-site.
+var indexPage = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gnsscal {{.SatSys}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+td { padding: 0 0.5em; }
+</style>
+</head>
+<body>
+<h1>{{.SatSys}} calendars</h1>
+<table>
+{{range .Years}}<tr><td><a href="{{.}}/index.html">{{.}}</a></td>{{$y := .}}{{range $.Months}}<td><a href="{{$y}}/{{.}}.html">{{.}}</a></td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// runGenSite writes static HTML calendars of years.
+func runGenSite(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("gen-site", flag.ExitOnError)
+	years := fs.String("years", "", "range of years, e.g. 2024-2030 [default: this year]")
+	out := fs.String("out", "site", "output directory")
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal gen-site [-years yyyy[-yyyy]] [-out dir] [-satsys sys]\n\n")
+		fmt.Fprintf(fs.Output(), "Pages:\n")
+		fmt.Fprintf(fs.Output(), "  index.html       links to all years and months\n")
+		fmt.Fprintf(fs.Output(), "  YYYY/index.html  calendar of a year\n")
+		fmt.Fprintf(fs.Output(), "  YYYY/MM.html     calendar of a month\n")
+	}
+	fs.Parse(args)
+
+	first, last := time.Now().Year(), time.Now().Year()
+	if *years != "" {
+		var err error
+		if first, last, err = parseYearRange(*years); err != nil {
+			return err
+		}
+	}
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+	q := url.Values{"satsys": {string(sys.Name)}}
+
+	// pages of months are in the directories of years, so links are
+	// relative to the parent of them.
+	link := func(t time.Time, yearly bool) string {
+		if t.Year() < first || t.Year() > last {
+			return ""
+		}
+		if yearly {
+			return fmt.Sprintf("../%04d/index.html", t.Year())
+		}
+		return fmt.Sprintf("../%04d/%02d.html", t.Year(), int(t.Month()))
+	}
+
+	write := func(path, file string) error {
+		cal, err := calFromQuery(path, q)
+		if err != nil {
+			return err
+		}
+		// a static page is not updated every day
+		cal.Highlight = false
+
+		prev, next, yearly := navDates(cal)
+		name := filepath.Join(*out, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		err = renderPage(f, page{
+			Title:    string(sys.Name) + " " + strings.Trim(path, "/"),
+			Calendar: cal.String(),
+			Prev:     link(prev, yearly),
+			Next:     link(next, yearly),
+			Up:       "../index.html",
+		})
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	}
+
+	var ys []int
+	for y := first; y <= last; y++ {
+		if err := write(fmt.Sprintf("/%04d", y), fmt.Sprintf("%04d/index.html", y)); err != nil {
+			return err
+		}
+		for m := 1; m <= 12; m++ {
+			if err := write(fmt.Sprintf("/%04d/%02d", y, m), fmt.Sprintf("%04d/%02d.html", y, m)); err != nil {
+				return err
+			}
+		}
+		ys = append(ys, y)
+	}
+
+	f, err := os.Create(filepath.Join(*out, "index.html"))
+	if err != nil {
+		return err
+	}
+	err = indexPage.Execute(f, map[string]interface{}{
+		"SatSys": sys.Name,
+		"Years":  ys,
+		"Months": []string{"01", "02", "03", "04", "05", "06", "07", "08", "09", "10", "11", "12"},
+	})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// parseYearRange parses a year, yyyy, or a range of years, yyyy-yyyy.
+func parseYearRange(s string) (first, last int, err error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		to = from
+	}
+	if first, err = strconv.Atoi(from); err != nil {
+		return 0, 0, fmt.Errorf("invalid year: %s, error: %v", from, err)
+	}
+	if last, err = strconv.Atoi(to); err != nil {
+		return 0, 0, fmt.Errorf("invalid year: %s, error: %v", to, err)
+	}
+	if first > last {
+		return 0, 0, fmt.Errorf("invalid range of years: %s", s)
+	}
+	return first, last, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/cache"
+	"github.com/satoshi-pes/gnsscal/calendar"
+	"github.com/satoshi-pes/gnsscal/gnss/spaceweather"
+)
+
+// spaceWeatherSources are the historical indices of GFZ and the forecast
+// of SWPC in the cache, refreshed daily.
+var spaceWeatherSources = []struct {
+	src  cache.Source
+	load func(data []byte) (*spaceweather.Indices, error)
+}{
+	{cache.Source{Name: "Kp_ap_Ap_SN_F107_since_1932.txt", URL: spaceweather.HistoryURL, MaxAge: oneDay}, loadGFZ},
+	{cache.Source{Name: "27-day-outlook.txt", URL: spaceweather.OutlookURL, MaxAge: oneDay}, loadOutlook},
+}
+
+func loadGFZ(data []byte) (*spaceweather.Indices, error) {
+	return spaceweather.LoadGFZ(bytes.NewReader(data))
+}
+
+func loadOutlook(data []byte) (*spaceweather.Indices, error) {
+	return spaceweather.LoadOutlook(bytes.NewReader(data))
+}
+
+// loadSpaceWeather returns the historical indices followed by the
+// forecast, each from the cache refreshed if older than a day or if
+// refresh is true. A source failing to refresh is used from the cache, or
+// skipped, with a warning; an error is returned if none is available.
+func loadSpaceWeather(ctx context.Context, refresh bool) (ind *spaceweather.Indices, warns []error, err error) {
+	for _, s := range spaceWeatherSources {
+		s.src.Check = func(data []byte) (time.Time, error) {
+			_, err := s.load(data)
+			return time.Time{}, err
+		}
+		data, _, err := cache.Get(ctx, s.src, refresh)
+		if data == nil {
+			warns = append(warns, fmt.Errorf("failed to load %s: %v", s.src.Name, err))
+			continue
+		}
+		if err != nil {
+			warns = append(warns, fmt.Errorf("failed to refresh %s: %v; using the cache", s.src.Name, err))
+		}
+		si, err := s.load(data)
+		if err != nil {
+			warns = append(warns, err)
+			continue
+		}
+		if ind == nil {
+			ind = si
+		} else {
+			ind = ind.Merge(si)
+		}
+	}
+	if ind == nil {
+		return nil, warns, fmt.Errorf("no space weather indices available")
+	}
+	return ind, warns, nil
+}
+
+// activeDays returns the days from first to last (exclusive) of which Kp
+// is kp or more, or F10.7 is f107 or more; the thresholds of 0 are not
+// applied.
+func activeDays(ind *spaceweather.Indices, first, last time.Time, kp, f107 float64) []calendar.ActiveDay {
+	days := []calendar.ActiveDay{}
+	for date := first; date.Before(last); date = date.Add(oneDay) {
+		e, ok := ind.At(date)
+		if !ok || !(kp > 0 && e.Kp >= kp || f107 > 0 && e.F107 >= f107) {
+			continue
+		}
+		label := fmt.Sprintf("Kp %.1f, Ap %d", e.Kp, e.Ap)
+		if e.F107 > 0 {
+			label += fmt.Sprintf(", F10.7 %.0f", e.F107)
+		}
+		if e.Predicted {
+			label += " (forecast)"
+		}
+		days = append(days, calendar.ActiveDay{Date: date, Label: label})
+	}
+	return days
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runTable prints the doy and GNSS week/dow of every day of years.
+func runTable(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("table", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	format := fs.String("format", "text", "output format; 'text', 'csv', 'xlsx', or 'parquet'")
+	jobs := fs.Int("jobs", runtime.NumCPU(), "number of goroutines rendering the years")
+	dates := fs.String("dates", "calendar", "notation of dates; 'calendar' (2024-05-02), 'ordinal' (2024-123), or 'week' (2024-W18-4)")
+	sheets := fs.String("sheets", "month", "sheets of xlsx; 'month' for a sheet per month, or 'flat' for a sheet of all days")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal table [-satsys sys] [-format text|csv|xlsx|parquet] [-sheets month|flat] [-dates style] [-jobs n] [year...]\n\n")
+		fmt.Fprintf(fs.Output(), "The xlsx workbook and the parquet file are written to stdout, to be redirected\n")
+		fmt.Fprintf(fs.Output(), "to a file; their dates are typed dates regardless of -dates. Today and GNSS\n")
+		fmt.Fprintf(fs.Output(), "events are highlighted by conditional formatting in xlsx.\n")
+	}
+	fs.Parse(args)
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+
+	ds, err := parseDateStyle(*dates)
+	if err != nil {
+		return err
+	}
+
+	years := []int{time.Now().Year()}
+	if fs.NArg() > 0 {
+		years = nil
+		for _, arg := range fs.Args() {
+			year, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid year: %s, error: %v", arg, err)
+			}
+			years = append(years, year)
+		}
+	}
+
+	switch *format {
+	case "text", "csv":
+	case "xlsx":
+		var perMonth bool
+		switch *sheets {
+		case "month":
+			perMonth = true
+		case "flat":
+		default:
+			return fmt.Errorf("invalid sheets: '%s'. valid sheets: month, flat", *sheets)
+		}
+		if isTerminal(os.Stdout) {
+			return fmt.Errorf("xlsx is not written to a terminal; redirect the output to a file")
+		}
+		out := bufio.NewWriter(os.Stdout)
+		if err := writeXLSX(out, sys, xlsxSheets(years, perMonth)); err != nil {
+			return err
+		}
+		return out.Flush()
+	case "parquet":
+		if isTerminal(os.Stdout) {
+			return fmt.Errorf("parquet is not written to a terminal; redirect the output to a file")
+		}
+		out := bufio.NewWriter(os.Stdout)
+		if err := writeParquet(ctx, out, sys, years); err != nil {
+			return err
+		}
+		return out.Flush()
+	default:
+		return fmt.Errorf("invalid format: '%s'. valid formats: text, csv, xlsx, parquet", *format)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	if *format == "text" {
+		fmt.Fprintf(out, "%-12s%-6s%-5s%-5s%6s%5s\n", "Date", "Year", "DOY", "Day", "Week", "Dow")
+	} else {
+		fmt.Fprintf(out, "date,year,doy,weekday,week,dow\n")
+	}
+
+	// years are rendered by jobs goroutines and printed in order
+	err = writeOrdered(ctx, out, *jobs, func(send func(task) bool) error {
+		for _, year := range years {
+			if !send(func() ([]byte, error) { return tableYear(sys, year, *format, ds) }) {
+				break
+			}
+		}
+		return nil
+	})
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// tableYear returns the rows of the table of year in format, text or csv,
+// with dates in ds.
+func tableYear(sys gnss.SystemInfo, year int, format string, ds dateStyle) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	firstDay := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for date := firstDay; date.Year() == year; date = date.Add(oneDay) {
+		week, dow := "", ""
+		if epoch := sys.EpochAt(date); !date.Before(epoch) {
+			week = strconv.Itoa(gnss.WeekNumber(date, epoch))
+			dow = strconv.Itoa(gnss.DayOfWeek(date, epoch))
+		}
+
+		if format == "csv" {
+			w.Write([]string{ds.date(date), strconv.Itoa(year), fmt.Sprintf("%03d", doy(date)), date.Weekday().String()[:3], week, dow})
+			continue
+		}
+		fmt.Fprintf(&b, "%-12s%-6d%03d  %-5s%6s%5s\n", ds.date(date), year, doy(date), date.Weekday().String()[:3], week, dow)
+	}
+	w.Flush()
+
+	return b.Bytes(), w.Error()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runUpcoming prints upcoming week rollovers and leap seconds.
+func runUpcoming(ctx context.Context, args []string) error {
+	now := time.Now().UTC()
+
+	fmt.Printf("%-22s%-12s%6s  %s\n", "Event", "Date", "Week", "Weeks remaining")
+	for _, e := range upcomingEvents(now) {
+		fmt.Printf("%-22s%-12s%6d  %d\n", e.Name, e.Date.Format("2006-01-02"), e.Week, int(e.Date.Sub(now)/oneWeek))
+	}
+
+	if _, expire, ok := gnss.NextLeapSecond(now); !ok {
+		fmt.Printf("%-22snone announced (table valid until %s)\n", "Leap second", expire.Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+// upcomingEvent is an event on a date; a week rollover, a leap second, or
+// a campaign.
+type upcomingEvent struct {
+	Name string
+	Date time.Time
+	Week int // week of the rollover, or GPS week of the date
+}
+
+// upcomingEvents returns the next week rollovers of the systems and the next
+// leap second announced after now.
+func upcomingEvents(now time.Time) (events []upcomingEvent) {
+	for _, sys := range gnss.Systems() {
+		week, date, ok := sys.NextRollover(now)
+		if !ok {
+			continue
+		}
+		events = append(events, upcomingEvent{Name: string(sys.Name) + " week rollover", Date: date, Week: week})
+	}
+
+	if next, _, ok := gnss.NextLeapSecond(now); ok {
+		// leap second is inserted at the end of the day before
+		date := next.Add(-oneDay)
+		g, _ := gnss.TimeOf(gnss.SYSGPS, date)
+		events = append(events, upcomingEvent{Name: "Leap second", Date: date, Week: g.Week})
+	}
+
+	return events
+}
+
+// readEvents reads campaign events, one per line in the form
+// "yyyy-mm-dd name". Empty lines and lines starting with '#' are ignored.
+func readEvents(r io.Reader) (events []upcomingEvent, err error) {
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		date, name, _ := strings.Cut(line, " ")
+		t, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date at line %d: %s, error: %v", n, date, err)
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("no name of event at line %d", n)
+		}
+		g, _ := gnss.TimeOf(gnss.SYSGPS, t)
+		events = append(events, upcomingEvent{Name: name, Date: t, Week: g.Week})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// loadEvents reads the campaign events of the file name.
+func loadEvents(name string) ([]upcomingEvent, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readEvents(f)
+}
+
+// sortEvents sorts events by date.
+func sortEvents(events []upcomingEvent) {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+import "os"
+
+// enableVT reports whether escape sequences are processed by the terminal
+// of f, which they are except on Windows consoles; see vt_windows.go.
+func enableVT(f *os.File) bool {
+	return true
+}
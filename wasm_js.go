@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"syscall/js"
+)
+
+// main of the js/wasm build exposes the conversion and rendering APIs to
+// JavaScript as the global object 'gnsscal':
+//
+//	gnsscal.convert(epoch[, satsys[, layout]])  epoch in the forms of query
+//	gnsscal.date(date[, satsys])                yyyy-mm-dd or yyyy:ddd
+//	gnsscal.week(week[, satsys])                days of a GNSS week
+//	gnsscal.calendar(year[, month[, options]])  calendar text
+//	gnsscal.calendarHTML(year[, month[, options]])
+//
+// The conversions return the JSON model of the query command as an object,
+// or {error: "..."} on errors. options of the calendars are an object of
+// the query parameters of the serve command, e.g. {satsys: "GAL", layout: "3month"}.
+//
+// Build with:
+//
+//	GOOS=js GOARCH=wasm go build -o gnsscal.wasm
+func main() {
+	js.Global().Set("gnsscal", js.ValueOf(map[string]interface{}{
+		"convert":      js.FuncOf(jsConvert),
+		"date":         js.FuncOf(jsDate),
+		"week":         js.FuncOf(jsWeek),
+		"calendar":     js.FuncOf(jsCalendar(false)),
+		"calendarHTML": js.FuncOf(jsCalendar(true)),
+	}))
+
+	select {} // keeps the functions alive
+}
+
+// jsArg returns the i-th argument as a string, or "" if not given.
+func jsArg(args []js.Value, i int) string {
+	if i >= len(args) || args[i].IsUndefined() || args[i].IsNull() {
+		return ""
+	}
+	if args[i].Type() == js.TypeNumber {
+		return strconv.FormatFloat(args[i].Float(), 'f', -1, 64)
+	}
+	return args[i].String()
+}
+
+// jsObject converts v to a JavaScript object through JSON.
+func jsObject(v interface{}) js.Value {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return jsError(err)
+	}
+	return js.Global().Get("JSON").Call("parse", string(b))
+}
+
+func jsError(err error) js.Value {
+	return js.ValueOf(map[string]interface{}{"error": err.Error()})
+}
+
+func jsConvert(this js.Value, args []js.Value) interface{} {
+	sys, err := lookupSatSysOrGPS(jsArg(args, 1))
+	if err != nil {
+		return jsError(err)
+	}
+	t, err := parseEpoch(sys, jsArg(args, 0), jsArg(args, 2))
+	if err != nil {
+		return jsError(err)
+	}
+	ti, err := newTimeInfo(sys, t)
+	if err != nil {
+		return jsError(err)
+	}
+	return jsObject(ti)
+}
+
+func jsDate(this js.Value, args []js.Value) interface{} {
+	sys, err := lookupSatSysOrGPS(jsArg(args, 1))
+	if err != nil {
+		return jsError(err)
+	}
+	date, err := parseDate(jsArg(args, 0))
+	if err != nil {
+		return jsError(err)
+	}
+	ti, err := newTimeInfo(sys, date)
+	if err != nil {
+		return jsError(err)
+	}
+	return jsObject(ti)
+}
+
+func jsWeek(this js.Value, args []js.Value) interface{} {
+	sys, err := lookupSatSysOrGPS(jsArg(args, 1))
+	if err != nil {
+		return jsError(err)
+	}
+	week, err := strconv.Atoi(jsArg(args, 0))
+	if err != nil || week < 0 {
+		return jsError(fmt.Errorf("invalid week: %s", jsArg(args, 0)))
+	}
+	info, err := newWeekInfo(sys, week)
+	if err != nil {
+		return jsError(err)
+	}
+	return jsObject(info)
+}
+
+// jsCalendar returns the function rendering a calendar as text, or as HTML
+// if html is true.
+func jsCalendar(html bool) func(this js.Value, args []js.Value) interface{} {
+	return func(this js.Value, args []js.Value) interface{} {
+		path := "/" + jsArg(args, 0)
+		if m := jsArg(args, 1); m != "" && m != "0" {
+			path += "/" + m
+		}
+		q := url.Values{}
+		if len(args) > 2 && args[2].Type() == js.TypeObject {
+			keys := js.Global().Get("Object").Call("keys", args[2])
+			for i := 0; i < keys.Length(); i++ {
+				k := keys.Index(i).String()
+				q.Set(k, jsArg([]js.Value{args[2].Get(k)}, 0))
+			}
+		}
+
+		cal, err := calFromQuery(path, q)
+		if err != nil {
+			return jsError(err)
+		}
+		if html {
+			return ansiToHTML(cal.String())
+		}
+		cal.Highlight = false
+		return cal.String()
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runWeekDir prints the GPS week directory of a date or week, or the dates
+// of a GPS week directory.
+func runWeekDir(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("weekdir", flag.ExitOnError)
+	root := fs.String("root", "products", "root of the GPS week directories")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal weekdir [-root dir] [yyyy-mm-dd | week | path]\n")
+	}
+	fs.Parse(args)
+
+	arg := time.Now().UTC().Format("2006-01-02")
+	if fs.NArg() > 0 {
+		arg = fs.Arg(0)
+	}
+
+	var week int
+	var start time.Time
+	if date, err := time.Parse("2006-01-02", arg); err == nil {
+		// date
+		g, err := gnss.FromSystemTime(gnss.SYSGPS, date)
+		if err != nil {
+			return err
+		}
+		week = g.Week
+		start = gnss.GPST0.AddDate(0, 0, 7*week)
+	} else if w, err := strconv.Atoi(arg); err == nil {
+		// week
+		week = w
+		start = gnss.GPST0.AddDate(0, 0, 7*week)
+	} else if strings.Contains(arg, "/") {
+		// path
+		if week, start, err = gnss.ParseWeekDir(arg); err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("invalid date, week or path: %s", arg)
+	}
+
+	end := start.Add(6 * oneDay)
+	fmt.Printf("%s  week=%d %s to %s doy=%03d-%03d\n", gnss.WeekDirOf(*root, week),
+		week, start.Format("2006-01-02"), end.Format("2006-01-02"), doy(start), doy(end))
+
+	return nil
+}
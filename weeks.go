@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// runWeeks prints a table of GNSS weeks touching a year.
+func runWeeks(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("weeks", flag.ExitOnError)
+	satsys := fs.String("satsys", "GPS", "satellite system of GNSS week to be shown")
+	dates := fs.String("dates", "calendar", "notation of dates; 'calendar' (2024-05-02), 'ordinal' (2024-123), or 'week' (2024-W18-4)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  gnsscal weeks [-satsys sys] [-dates style] [year]\n")
+	}
+	fs.Parse(args)
+
+	sys, err := lookupSatSys(*satsys)
+	if err != nil {
+		return err
+	}
+
+	ds, err := parseDateStyle(*dates)
+	if err != nil {
+		return err
+	}
+
+	year := time.Now().Year()
+	if fs.NArg() > 0 {
+		var err error
+		if year, err = strconv.Atoi(fs.Arg(0)); err != nil {
+			return fmt.Errorf("invalid year: %s, error: %v", fs.Arg(0), err)
+		}
+	}
+
+	fmt.Printf("%s weeks of %d\n", sys.Name, year)
+	fmt.Printf("%-6s%-12s%-12s%s\n", "Week", "Start", "End", "DOY")
+	for _, r := range weeksOfYear(sys, year) {
+		fmt.Printf("%4d  %-12s%-12s%03d-%03d\n",
+			r.Week, ds.date(r.Start), ds.date(r.End), doy(r.Start), doy(r.End))
+	}
+
+	return nil
+}
+
+// weeksOfYear returns GNSS weeks touching the year, including weeks cut
+// short by the restart of week counting (GLONASS).
+func weeksOfYear(sys gnss.SystemInfo, year int) []gnss.Week {
+	firstDay := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	return slices.Collect(gnss.Weeks(sys.Name, firstDay, lastDay))
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/calendar"
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+// xlsxSheet is a worksheet of the day table; the days of Ranges in order.
+type xlsxSheet struct {
+	Name   string
+	Ranges []gnss.DateRange
+}
+
+// xlsxSheets returns the sheets of the days of years; one per month if
+// perMonth is true, e.g. "Jan 2024", or else one sheet "Days". The years
+// are in order, and a year given twice is added once, as the names of
+// sheets must be unique in a workbook and the days of a sheet too.
+func xlsxSheets(years []int, perMonth bool) []xlsxSheet {
+	years = slices.Compact(slices.Sorted(slices.Values(years)))
+	if !perMonth {
+		days := xlsxSheet{Name: "Days"}
+		for _, year := range years {
+			days.Ranges = append(days.Ranges, gnss.DateRange{
+				Start: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+			})
+		}
+		return []xlsxSheet{days}
+	}
+	var sheets []xlsxSheet
+	for _, year := range years {
+		for m := time.January; m <= time.December; m++ {
+			start := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+			sheets = append(sheets, xlsxSheet{start.Format("Jan 2006"), []gnss.DateRange{{Start: start, End: start.AddDate(0, 1, -1)}}})
+		}
+	}
+	return sheets
+}
+
+// writeXLSX writes an Office Open XML workbook of the days of sheets with
+// the columns of date, doy, GNSS week and dow of sys, weekday, and the
+// GNSS events of the day. The rows of today (by TODAY() of the spreadsheet)
+// and of events are highlighted by conditional formatting.
+func writeXLSX(w io.Writer, sys gnss.SystemInfo, sheets []xlsxSheet) error {
+	events := make(map[time.Time][]string)
+	for _, e := range calendar.GNSSEvents() {
+		events[e.Date] = append(events[e.Date], e.Name)
+	}
+
+	zw := zip.NewWriter(w)
+	add := func(name, content string) error {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, content)
+		return err
+	}
+
+	var types, rels, list strings.Builder
+	for i, s := range sheets {
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+		fmt.Fprintf(&list, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlText(s.Name), i+1, i+1)
+	}
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			types.String() + `</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + list.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() + `</Relationships>`},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		if err := add(p.name, p.content); err != nil {
+			return err
+		}
+	}
+
+	for i, s := range sheets {
+		if err := add(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxWorksheet(sys, s, events)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// xlsxStyles are the styles of the workbook: cell formats of 0 default, 1
+// dates, and 2 headers in bold, and differential formats of 0 today in
+// reversed colors and 1 events in bold blue, as the calendars mark them.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`<dxfs count="2"><dxf><font><b/><color rgb="FFFFFFFF"/></font><fill><patternFill><bgColor rgb="FF333333"/></patternFill></fill></dxf>` +
+	`<dxf><font><b/><color rgb="FF0066CC"/></font></dxf></dxfs>` +
+	`</styleSheet>`
+
+// xlsxColumns are the headers and widths of the columns of the sheets.
+var xlsxColumns = []struct {
+	name  string
+	width int
+}{{"Date", 12}, {"DOY", 6}, {"Week", 7}, {"Dow", 5}, {"Weekday", 10}, {"Event", 40}}
+
+// xlsxWorksheet returns the worksheet of the days of s with the frozen
+// header row.
+func xlsxWorksheet(sys gnss.SystemInfo, s xlsxSheet, events map[time.Time][]string) string {
+	var b bytes.Buffer
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><cols>`)
+	for i, col := range xlsxColumns {
+		fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, col.width)
+	}
+	b.WriteString(`</cols><sheetData><row r="1">`)
+	for i, col := range xlsxColumns {
+		fmt.Fprintf(&b, `<c r="%c1" t="inlineStr" s="2"><is><t>%s</t></is></c>`, 'A'+i, col.name)
+	}
+	b.WriteString(`</row>`)
+
+	r := 1
+	for _, dr := range s.Ranges {
+		for date := dr.Start; !date.After(dr.End); date = date.Add(oneDay) {
+			r++
+			writeXLSXRow(&b, sys, r, date, events)
+		}
+	}
+	b.WriteString(`</sheetData>`)
+
+	fmt.Fprintf(&b, `<conditionalFormatting sqref="A2:F%d">`, r)
+	b.WriteString(`<cfRule type="expression" dxfId="0" priority="1"><formula>$A2=TODAY()</formula></cfRule>`)
+	b.WriteString(`<cfRule type="expression" dxfId="1" priority="2"><formula>LEN($F2)&gt;0</formula></cfRule>`)
+	b.WriteString(`</conditionalFormatting></worksheet>`)
+	return b.String()
+}
+
+// writeXLSXRow writes the row r of date to b.
+func writeXLSXRow(b *bytes.Buffer, sys gnss.SystemInfo, r int, date time.Time, events map[time.Time][]string) {
+	fmt.Fprintf(b, `<row r="%d"><c r="A%d" s="1"><v>%d</v></c><c r="B%d"><v>%d</v></c>`, r, r, excelSerial(date), r, doy(date))
+	if epoch := sys.EpochAt(date); !date.Before(epoch) {
+		fmt.Fprintf(b, `<c r="C%d"><v>%d</v></c><c r="D%d"><v>%d</v></c>`, r, gnss.WeekNumber(date, epoch), r, gnss.DayOfWeek(date, epoch))
+	}
+	fmt.Fprintf(b, `<c r="E%d" t="inlineStr"><is><t>%s</t></is></c>`, r, date.Weekday().String()[:3])
+	if names := events[date]; len(names) > 0 {
+		fmt.Fprintf(b, `<c r="F%d" t="inlineStr"><is><t>%s</t></is></c>`, r, xmlText(strings.Join(names, "; ")))
+	}
+	b.WriteString(`</row>`)
+}
+
+// excelSerial returns the serial number of date in spreadsheets, the days
+// from 1899-12-30 (by which 1900 counts as a leap year as in Lotus 1-2-3).
+func excelSerial(date time.Time) int {
+	return gnss.DaysBetween(time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC), date)
+}
+
+// xmlText returns s escaped for the text of XML.
+func xmlText(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/satoshi-pes/gnsscal/gnss"
+)
+
+func TestExcelSerial(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want int
+	}{
+		{time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC), 61},
+		{time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC), 29226},
+		{time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC), 45414},
+	}
+	for _, tt := range tests {
+		if got := excelSerial(tt.date); got != tt.want {
+			t.Errorf("excelSerial(%s) = %d, want %d", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+// readXLSX returns the parts of the workbook of sheets by name.
+func readXLSX(t *testing.T, sheets []xlsxSheet) map[string]string {
+	t.Helper()
+	sys, _ := gnss.Lookup(string(gnss.SYSGPS))
+	var buf bytes.Buffer
+	if err := writeXLSX(&buf, sys, sheets); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := xml.Unmarshal(b, new(struct{})); err != nil {
+			t.Errorf("%s is not well-formed: %v", f.Name, err)
+		}
+		parts[f.Name] = string(b)
+	}
+	return parts
+}
+
+func TestWriteXLSX(t *testing.T) {
+	parts := readXLSX(t, xlsxSheets([]int{2024, 2023, 2024}, true))
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet24.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("no part %s", name)
+		}
+	}
+	if len(parts) != 5+24 {
+		t.Errorf("%d parts, want %d", len(parts), 5+24)
+	}
+
+	var wb struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal([]byte(parts["xl/workbook.xml"]), &wb); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range wb.Sheets {
+		names = append(names, s.Name)
+	}
+	if len(names) != 24 || names[0] != "Jan 2023" || names[12] != "Jan 2024" || names[23] != "Dec 2024" {
+		t.Errorf("sheets = %v", names)
+	}
+	if len(slices.Compact(slices.Clone(names))) != len(names) {
+		t.Errorf("duplicate sheet names: %v", names)
+	}
+
+	// 2023-01-01 is the serial 44927 on Sunday of GPS week 2243
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if want := `<row r="2"><c r="A2" s="1"><v>44927</v></c><c r="B2"><v>1</v></c><c r="C2"><v>2243</v></c><c r="D2"><v>0</v></c>`; !strings.Contains(sheet, want) {
+		t.Errorf("first row of Jan 2023 is not %s", want)
+	}
+	if !strings.Contains(sheet, `<row r="32">`) || strings.Contains(sheet, `<row r="33">`) {
+		t.Errorf("Jan 2023 does not have 31 rows")
+	}
+}
+
+func TestWriteXLSXFlat(t *testing.T) {
+	parts := readXLSX(t, xlsxSheets([]int{2024, 1979, 2024}, false))
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="Days" sheetId="1" r:id="rId1"/></sheets>`) {
+		t.Errorf("workbook has other than the sheet Days")
+	}
+
+	// the days of 1979 and of 2024 once, without the weeks before GPST0
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	rows := 1 + 365 + 366
+	if n := strings.Count(sheet, "<row "); n != rows {
+		t.Errorf("%d rows, want %d", n, rows)
+	}
+	if !strings.Contains(sheet, `<row r="2"><c r="A2" s="1"><v>28856</v></c><c r="B2"><v>1</v></c><c r="E2" t="inlineStr">`) {
+		t.Errorf("first row of 1979 is not of 1979-01-01 without the week")
+	}
+	if !strings.Contains(sheet, `<row r="367"><c r="A367" s="1"><v>45292</v></c>`) {
+		t.Errorf("2024 does not follow 1979")
+	}
+}